@@ -15,14 +15,24 @@
 package gomock
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
 
 	"github.com/google/go-cmp/cmp"
 )
 
+// errMismatch is returned by Call.match when the arguments don't match and no
+// explanation was requested.
+var errMismatch = errors.New("call doesn't match")
+
 // Call represents an expected call to a mock.
 type Call struct {
 	t TestHelper // for triggering test failures on invalid call setup
@@ -34,18 +44,43 @@ type Call struct {
 	origin     string       // file and line number of call setup
 
 	preReqs []*Call // prerequisite calls
+	group   []*Call // the AllOrNone group of the call, including itself
 
 	// Expectations
 	minCalls, maxCalls int
+	// countOK, if set by TimesMatching, decides at Finish whether numCalls
+	// is acceptable, in place of minCalls.
+	countOK func(n int) bool
 
-	numCalls int // actual number made
+	numCalls int        // actual number made
+	countMu  sync.Mutex // held with the Controller's lock when numCalls changes
 
 	// actions are called when this Call is called. Each action gets the args and
 	// can set the return values by returning a non-nil slice. Actions run in the
 	// order they are created.
 	actions []func([]any) []any
+	// overrides are the DoOrReturn callbacks, tried in order before actions.
+	overrides []func(args []any) ([]any, bool)
+
+	cmpOpts      cmp.Options // comparison options
+	reflectEqual bool        // don't use Differ in failure messages
+	maxDiffLen   int         // max runes of a Differ's diff to report, if > 0
+}
 
-	cmpOpts cmp.Options // comparison options
+// argTypeChecker is implemented by matchers that can validate, when an
+// expectation is recorded, that they are usable with the parameter type of
+// the mocked method. checkArgType panics if they are not.
+type argTypeChecker interface {
+	checkArgType(t reflect.Type)
+}
+
+// cmpOptsReceiver is implemented by matchers that compare values using the
+// cmp.Options of the Controller they are recorded with. withBaseCmpOpts
+// returns a copy of the matcher holding opts, by convention in a field named
+// base; values without a Matcher of their own are then compared with
+// equalWithBase.
+type cmpOptsReceiver interface {
+	withBaseCmpOpts(opts cmp.Options) Matcher
 }
 
 // newCall creates a *Call. It requires the method type in order to support
@@ -65,19 +100,26 @@ func newCall(t TestHelper, receiver any, method string, methodType reflect.Type,
 		} else {
 			mArgs[i] = Eq(arg)
 		}
+		if r, ok := mArgs[i].(cmpOptsReceiver); ok {
+			mArgs[i] = r.withBaseCmpOpts(cmpOpts)
+		}
+		if tc, ok := mArgs[i].(argTypeChecker); ok && i < methodType.NumIn() {
+			tc.checkArgType(methodType.In(i))
+		}
 	}
 
 	// callerInfo's skip should be updated if the number of calls between the user's test
 	// and this line changes, i.e. this code is wrapped in another anonymous function.
 	// 0 is us, 1 is RecordCallWithMethodType(), 2 is the generated recorder, and 3 is the user's test.
 	origin := callerInfo(3)
+	// Synthesize the zero value for each of the return args' types once, as
+	// the call may be made many times.
+	zero := make([]any, methodType.NumOut())
+	for i := range zero {
+		zero[i] = reflect.Zero(methodType.Out(i)).Interface()
+	}
 	actions := []func([]any) []any{func([]any) []any {
-		// Synthesize the zero value for each of the return args' types.
-		rets := make([]any, methodType.NumOut())
-		for i := 0; i < methodType.NumOut(); i++ {
-			rets[i] = reflect.Zero(methodType.Out(i)).Interface()
-		}
-		return rets
+		return zero
 	}}
 	return &Call{t: t, receiver: receiver, method: method, methodType: methodType,
 		args: mArgs, origin: origin, minCalls: 1, maxCalls: 1, actions: actions,
@@ -110,6 +152,18 @@ func (c *Call) MaxTimes(n int) *Call {
 	return c
 }
 
+// Between requires the call to occur at least min and at most max times. It is
+// equivalent to MinTimes(min).MaxTimes(max), so a min of 1 is reset to 0 as
+// described for MaxTimes. Between panics if min is negative or greater than
+// max.
+func (c *Call) Between(min, max int) *Call {
+	if min < 0 || min > max {
+		panic(fmt.Sprintf("gomock: invalid bounds for Between(%d, %d) on %T.%v [%s]",
+			min, max, c.receiver, c.method, c.origin))
+	}
+	return c.MinTimes(min).MaxTimes(max)
+}
+
 // DoAndReturn declares the action to run when the call is matched.
 // The return values from this function are returned by the mocked function.
 // It takes an any argument to support n-arity functions.
@@ -131,18 +185,86 @@ func (c *Call) DoAndReturn(f any) *Call {
 			}
 			return nil
 		}
-		vArgs := make([]reflect.Value, len(args))
-		for i := 0; i < len(args); i++ {
-			if args[i] != nil {
-				vArgs[i] = reflect.ValueOf(args[i])
-			} else {
-				// Use the zero value for the arg.
-				vArgs[i] = reflect.Zero(ft.In(i))
-			}
-		}
+		vArgs := funcArgs(ft, args)
 		vRets := v.Call(vArgs)
 		rets := make([]any, len(vRets))
 		for i, ret := range vRets {
+			if i < c.methodType.NumOut() {
+				if out := c.methodType.Out(i); ret.Type() != out && ret.Type().AssignableTo(out) {
+					// Convert to the result type so that the generated code can
+					// return the value with a type assertion.
+					converted := reflect.New(out).Elem()
+					converted.Set(ret)
+					ret = converted
+				}
+			}
+			rets[i] = ret.Interface()
+		}
+		return rets
+	})
+	return c
+}
+
+// DoAndReturnNamed is like DoAndReturn, but f returns a single struct whose
+// fields are the results of the mocked method, which can make callbacks for
+// methods with several results easier to read:
+//
+//	m.EXPECT().Count().DoAndReturnNamed(func() (r struct {
+//	  Count int
+//	  Err   error
+//	}) {
+//	  r.Count = 3
+//	  return r
+//	})
+//
+// Result names are not available through reflection, so the fields are mapped
+// to the results by position; their names only serve as documentation. The
+// struct must have exactly one exported field per result, each assignable to
+// the result's type. This is checked when DoAndReturnNamed is called.
+func (c *Call) DoAndReturnNamed(f any) *Call {
+	c.t.Helper()
+
+	v := reflect.ValueOf(f)
+	ft := v.Type()
+	if ft.Kind() != reflect.Func || ft.NumOut() != 1 || ft.Out(0).Kind() != reflect.Struct {
+		c.t.Fatalf("DoAndReturnNamed for %T.%v takes a func returning a struct, got %v [%s]",
+			c.receiver, c.method, ft, c.origin)
+		return c
+	}
+	if ft.NumIn() != c.methodType.NumIn() {
+		c.t.Fatalf("wrong number of arguments in DoAndReturnNamed func for %T.%v: got %d, want %d [%s]",
+			c.receiver, c.method, ft.NumIn(), c.methodType.NumIn(), c.origin)
+		return c
+	}
+	st := ft.Out(0)
+	if st.NumField() != c.methodType.NumOut() {
+		c.t.Fatalf("wrong number of fields in DoAndReturnNamed result for %T.%v: got %d, want %d [%s]",
+			c.receiver, c.method, st.NumField(), c.methodType.NumOut(), c.origin)
+		return c
+	}
+	for i := 0; i < st.NumField(); i++ {
+		field, out := st.Field(i), c.methodType.Out(i)
+		if !field.IsExported() {
+			c.t.Fatalf("field %s of DoAndReturnNamed result for %T.%v is not exported [%s]",
+				field.Name, c.receiver, c.method, c.origin)
+			return c
+		}
+		if !field.Type.AssignableTo(out) {
+			c.t.Fatalf("field %s of DoAndReturnNamed result for %T.%v has type %v, which is not assignable to result %d of type %v [%s]",
+				field.Name, c.receiver, c.method, field.Type, i, out, c.origin)
+			return c
+		}
+	}
+
+	c.addAction(func(args []any) []any {
+		c.t.Helper()
+		result := v.Call(funcArgs(ft, args))[0]
+		rets := make([]any, result.NumField())
+		for i := range rets {
+			// Convert to the result type so that the generated code can
+			// return the value with a type assertion.
+			ret := reflect.New(c.methodType.Out(i)).Elem()
+			ret.Set(result.Field(i))
 			rets[i] = ret.Interface()
 		}
 		return rets
@@ -150,6 +272,104 @@ func (c *Call) DoAndReturn(f any) *Call {
 	return c
 }
 
+// DoAndReturnArgs declares the action to run when the call is matched. Unlike
+// DoAndReturn, fn receives the arguments as a slice and returns the values
+// for the mocked function as a slice, which makes it possible to write
+// generic callbacks, e.g. for logging or forwarding. For variadic methods the
+// variadic arguments are passed as individual trailing elements of args.
+// The returned slice must match the number and types of the method's results.
+func (c *Call) DoAndReturnArgs(fn func(args []any) []any) *Call {
+	c.addAction(func(args []any) []any {
+		c.t.Helper()
+		rets := fn(args)
+		c.checkReturnValues("DoAndReturnArgs", rets)
+		return rets
+	})
+	return c
+}
+
+// DoOrReturn declares a callback that decides at call time whether to handle
+// the call itself. If fn returns handled set, the call returns its returns,
+// which must match the number and types of the method's results, and the
+// other actions of the call, such as Return or Do, are not run. Otherwise the
+// call falls through to those actions as if fn wasn't there. This allows
+// conditional stubbing in one place:
+//
+//	m.EXPECT().Get(gomock.Any()).AnyTimes().Return(0, nil).DoOrReturn(
+//	  func(args []any) ([]any, bool) {
+//	    if args[0] == "missing" {
+//	      return []any{0, errNotFound}, true
+//	    }
+//	    return nil, false
+//	  })
+//
+// Multiple DoOrReturn callbacks are tried in the order they were declared,
+// until one handles the call.
+func (c *Call) DoOrReturn(fn func(args []any) (returns []any, handled bool)) *Call {
+	c.overrides = append(c.overrides, fn)
+	return c
+}
+
+// DoAndReturnN is like DoAndReturnArgs, but fn also receives the zero-based
+// index of the invocation, i.e. 0 for the first time the call is matched, 1
+// for the second, and so on. This allows computing different results for
+// successive calls without keeping a counter in a closure.
+func (c *Call) DoAndReturnN(fn func(callIndex int, args []any) []any) *Call {
+	var next atomic.Int64
+	c.addAction(func(args []any) []any {
+		c.t.Helper()
+		rets := fn(int(next.Add(1)-1), args)
+		c.checkReturnValues("DoAndReturnN", rets)
+		return rets
+	})
+	return c
+}
+
+// DoWithContext declares an action that calls fn with the context.Context
+// passed as the first argument of the mocked method, e.g. to cancel a context
+// derived from it or to wait for it to be done. It fails the test if the
+// method's first parameter is not a context.Context.
+func (c *Call) DoWithContext(fn func(ctx context.Context)) *Call {
+	c.t.Helper()
+
+	ctxType := reflect.TypeOf((*context.Context)(nil)).Elem()
+	if mt := c.methodType; mt.NumIn() == 0 || mt.In(0) != ctxType {
+		c.t.Fatalf("DoWithContext called for %T.%v, whose first argument is not a context.Context [%s]",
+			c.receiver, c.method, c.origin)
+		return c
+	}
+
+	c.addAction(func(args []any) []any {
+		ctx, _ := args[0].(context.Context)
+		fn(ctx)
+		return nil
+	})
+	return c
+}
+
+// DoContext is like DoWithContext, but fn also receives the remaining
+// arguments of the call, i.e. all arguments but the context. For variadic
+// methods the variadic arguments are passed as individual trailing elements
+// of args. It fails the test if the method's first parameter is not a
+// context.Context.
+func (c *Call) DoContext(fn func(ctx context.Context, args ...any)) *Call {
+	c.t.Helper()
+
+	ctxType := reflect.TypeOf((*context.Context)(nil)).Elem()
+	if mt := c.methodType; mt.NumIn() == 0 || mt.In(0) != ctxType {
+		c.t.Fatalf("DoContext called for %T.%v, whose first argument is not a context.Context [%s]",
+			c.receiver, c.method, c.origin)
+		return c
+	}
+
+	c.addAction(func(args []any) []any {
+		ctx, _ := args[0].(context.Context)
+		fn(ctx, args[1:]...)
+		return nil
+	})
+	return c
+}
+
 // Do declares the action to run when the call is matched. The function's
 // return values are ignored to retain backward compatibility. To use the
 // return values call DoAndReturn.
@@ -172,29 +392,241 @@ func (c *Call) Do(f any) *Call {
 			}
 			return nil
 		}
-		vArgs := make([]reflect.Value, len(args))
-		for i := 0; i < len(args); i++ {
-			if args[i] != nil {
-				vArgs[i] = reflect.ValueOf(args[i])
-			} else {
-				// Use the zero value for the arg.
-				vArgs[i] = reflect.Zero(ft.In(i))
-			}
-		}
+		vArgs := funcArgs(ft, args)
 		v.Call(vArgs)
 		return nil
 	})
 	return c
 }
 
+// paramType returns the type of the ith argument passed to a func of type ft,
+// accounting for variadic arguments, or nil if there is no such argument.
+func paramType(ft reflect.Type, i int) reflect.Type {
+	switch {
+	case ft.IsVariadic() && i >= ft.NumIn()-1:
+		return ft.In(ft.NumIn() - 1).Elem()
+	case i < ft.NumIn():
+		return ft.In(i)
+	}
+	return nil
+}
+
+// funcArgs returns args as values to call a function of type ft with. Each
+// argument assignable to its parameter is converted to the parameter type, so
+// parameters may have more general types than the mocked method, such as any.
+// Nil arguments are passed as the zero value of the parameter type.
+func funcArgs(ft reflect.Type, args []any) []reflect.Value {
+	vArgs := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		pt := paramType(ft, i)
+		switch v := reflect.ValueOf(arg); {
+		case arg == nil && pt != nil:
+			// Use the zero value for the arg.
+			vArgs[i] = reflect.Zero(pt)
+		case pt != nil && v.Type().AssignableTo(pt):
+			converted := reflect.New(pt).Elem()
+			converted.Set(v)
+			vArgs[i] = converted
+		default:
+			// Let Call report the mismatch.
+			vArgs[i] = v
+		}
+	}
+	return vArgs
+}
+
+// Delay declares that the mocked function call blocks for d before
+// returning. The controller holds no locks while sleeping, so concurrent
+// calls to the mock are not serialized by the delay.
+//
+// Delay makes tests slower and is intended for exercising timeout and
+// cancellation behavior of the code under test.
+func (c *Call) Delay(d time.Duration) *Call {
+	c.addAction(func([]any) []any {
+		time.Sleep(d)
+		return nil
+	})
+	return c
+}
+
 // Return declares the values to be returned by the mocked function call.
+// An untyped nil may be given for results of pointer, interface, slice, map,
+// channel and func types, and is returned as the nil value of that type, e.g.
+// Return(nil) for an error result returns a nil error. Giving nil for a
+// result of any other type fails the test.
 func (c *Call) Return(rets ...any) *Call {
 	c.t.Helper()
 
+	c.checkReturnValues("Return", rets)
+
+	c.addAction(func([]any) []any {
+		return rets
+	})
+
+	return c
+}
+
+// ReturnNilError declares that the mocked function call returns a nil error,
+// and the zero value for any other results. The last result of the mocked
+// function must be an error.
+func (c *Call) ReturnNilError() *Call {
+	c.t.Helper()
+
+	mt := c.methodType
+	errType := reflect.TypeOf((*error)(nil)).Elem()
+	if mt.NumOut() == 0 || mt.Out(mt.NumOut()-1) != errType {
+		c.t.Fatalf("ReturnNilError called for %T.%v, whose last result is not an error [%s]",
+			c.receiver, c.method, c.origin)
+		return c
+	}
+
+	rets := make([]any, mt.NumOut())
+	for i := range rets[:len(rets)-1] {
+		rets[i] = reflect.Zero(mt.Out(i)).Interface()
+	}
+	c.addAction(func([]any) []any {
+		return rets
+	})
+
+	return c
+}
+
+// ReturnFromMap declares that the mocked function call returns the tuple of
+// values that table holds for the argument at index argIndex, which makes it
+// easy to stub lookups:
+//
+//	m.EXPECT().Get(gomock.Any()).AnyTimes().ReturnFromMap(0, map[any][]any{
+//	  "a": {1, nil},
+//	  "b": {2, nil},
+//	})
+//
+// The tuples are checked against the results of the mocked function when
+// ReturnFromMap is called. A call whose argument has no entry in table fails
+// the test. Keys are compared with ==, so they must have the argument's
+// dynamic type, e.g. int64(1) rather than 1 for an int64 argument.
+func (c *Call) ReturnFromMap(argIndex int, table map[any][]any) *Call {
+	c.t.Helper()
+
+	mt := c.methodType
+	if argIndex < 0 || (!mt.IsVariadic() && argIndex >= mt.NumIn()) {
+		c.t.Fatalf("ReturnFromMap(%d, ...) called for a method with %d args [%s]",
+			argIndex, mt.NumIn(), c.origin)
+		return c
+	}
+	rets := make(map[any][]any, len(table))
+	for k, v := range table {
+		v = append([]any(nil), v...)
+		c.checkReturnValues("ReturnFromMap", v)
+		rets[k] = v
+	}
+
+	c.addAction(func(args []any) []any {
+		c.t.Helper()
+		if argIndex >= len(args) {
+			c.t.Fatalf("ReturnFromMap(%d, ...) called for a call of %T.%v with %d args [%s]",
+				argIndex, c.receiver, c.method, len(args), c.origin)
+			return nil
+		}
+		arg := args[argIndex]
+		if t := reflect.TypeOf(arg); t != nil && !t.Comparable() {
+			c.t.Fatalf("ReturnFromMap(%d, ...) called with a %T argument, which can't be a map key [%s]",
+				argIndex, arg, c.origin)
+			return nil
+		}
+		r, ok := rets[arg]
+		if !ok {
+			c.t.Fatalf("ReturnFromMap(%d, ...) has no entry for argument %v (%T) [%s]",
+				argIndex, getString(arg), arg, c.origin)
+			return nil
+		}
+		return r
+	})
+	return c
+}
+
+// ReturnSequence declares the values to be returned by successive calls of
+// the mocked function. Each element of values is the full return tuple for
+// one invocation. Once the sequence is used up, the last tuple is returned
+// for any further invocations, unless an exact call count was declared with
+// Times, in which case exceeding the sequence is a fatal error.
+func (c *Call) ReturnSequence(values ...[]any) *Call {
+	c.t.Helper()
+
+	if len(values) == 0 {
+		c.t.Fatalf("ReturnSequence for %T.%v requires at least one return tuple [%s]",
+			c.receiver, c.method, c.origin)
+		return c
+	}
+	for i, rets := range values {
+		c.checkReturnValues(fmt.Sprintf("ReturnSequence tuple %d", i), rets)
+	}
+
+	var next atomic.Int64
+	c.addAction(func([]any) []any {
+		c.t.Helper()
+		i := int(next.Add(1) - 1)
+		if i >= len(values) {
+			if c.minCalls == c.maxCalls {
+				c.t.Fatalf("ReturnSequence for %T.%v has %d return tuples, but the call was made %d times [%s]",
+					c.receiver, c.method, len(values), i+1, c.origin)
+			}
+			i = len(values) - 1
+		}
+		return values[i]
+	})
+
+	return c
+}
+
+// ReturnErrorThenSuccess declares that the first errCount calls of the
+// mocked function return err, with the zero value for every other result,
+// and that any later calls return then. This models a dependency that
+// recovers after failing a number of times, e.g. to test retry logic.
+// The last result of the mocked function must be an error.
+func (c *Call) ReturnErrorThenSuccess(errCount int, err error, then ...any) *Call {
+	c.t.Helper()
+
+	mt := c.methodType
+	errType := reflect.TypeOf((*error)(nil)).Elem()
+	if mt.NumOut() == 0 || mt.Out(mt.NumOut()-1) != errType {
+		c.t.Fatalf("ReturnErrorThenSuccess called for %T.%v, whose last result is not an error [%s]",
+			c.receiver, c.method, c.origin)
+		return c
+	}
+	if errCount < 0 {
+		c.t.Fatalf("ReturnErrorThenSuccess for %T.%v called with negative errCount %d [%s]",
+			c.receiver, c.method, errCount, c.origin)
+		return c
+	}
+	c.checkReturnValues("ReturnErrorThenSuccess", then)
+
+	failure := make([]any, mt.NumOut())
+	for i := range failure[:len(failure)-1] {
+		failure[i] = reflect.Zero(mt.Out(i)).Interface()
+	}
+	failure[len(failure)-1] = err
+
+	var calls atomic.Int64
+	c.addAction(func([]any) []any {
+		if calls.Add(1) <= int64(errCount) {
+			return failure
+		}
+		return then
+	})
+
+	return c
+}
+
+// checkReturnValues fails the test if rets is not a valid return tuple for
+// the mocked method. Values of assignable types are converted in place to
+// the method's result types. name identifies the caller in failure messages.
+func (c *Call) checkReturnValues(name string, rets []any) {
+	c.t.Helper()
+
 	mt := c.methodType
 	if len(rets) != mt.NumOut() {
-		c.t.Fatalf("wrong number of arguments to Return for %T.%v: got %d, want %d [%s]",
-			c.receiver, c.method, len(rets), mt.NumOut(), c.origin)
+		c.t.Fatalf("wrong number of arguments to %s for %T.%v: got %d, want %d [%s]",
+			name, c.receiver, c.method, len(rets), mt.NumOut(), c.origin)
 	}
 	for i, ret := range rets {
 		if got, want := reflect.TypeOf(ret), mt.Out(i); got == want {
@@ -205,8 +637,8 @@ func (c *Call) Return(rets ...any) *Call {
 			case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
 				// ok
 			default:
-				c.t.Fatalf("argument %d to Return for %T.%v is nil, but %v is not nillable [%s]",
-					i, c.receiver, c.method, want, c.origin)
+				c.t.Fatalf("argument %d to %s for %T.%v is nil, but %v is not nillable [%s]",
+					i, name, c.receiver, c.method, want, c.origin)
 			}
 		} else if got.AssignableTo(want) {
 			// Assignable type relation. Make the assignment now so that the generated code
@@ -215,16 +647,10 @@ func (c *Call) Return(rets ...any) *Call {
 			v.Set(reflect.ValueOf(ret))
 			rets[i] = v.Interface()
 		} else {
-			c.t.Fatalf("wrong type of argument %d to Return for %T.%v: %v is not assignable to %v [%s]",
-				i, c.receiver, c.method, got, want, c.origin)
+			c.t.Fatalf("wrong type of argument %d to %s for %T.%v: %v is not assignable to %v [%s]",
+				i, name, c.receiver, c.method, got, want, c.origin)
 		}
 	}
-
-	c.addAction(func([]any) []any {
-		return rets
-	})
-
-	return c
 }
 
 // Times declares the exact number of times a function call is expected to be executed.
@@ -233,22 +659,59 @@ func (c *Call) Times(n int) *Call {
 	return c
 }
 
+// TimesMatching allows the call to be made any number of times, and has
+// Finish check the total number of calls with m: the test fails if m returns
+// false. It replaces any bounds set by Times, MinTimes, MaxTimes or AnyTimes.
+//
+// Example usage:
+//
+//	m.EXPECT().Flush().TimesMatching(func(n int) bool { return n%2 == 0 })
+func (c *Call) TimesMatching(m func(n int) bool) *Call {
+	c.minCalls, c.maxCalls = 0, 1e8
+	c.countOK = m
+	return c
+}
+
+// Once declares that the call is expected exactly once. It is equivalent to Times(1).
+func (c *Call) Once() *Call {
+	return c.Times(1)
+}
+
+// Twice declares that the call is expected exactly twice. It is equivalent to Times(2).
+func (c *Call) Twice() *Call {
+	return c.Times(2)
+}
+
+// Never declares that the call must not happen. It is equivalent to Times(0).
+func (c *Call) Never() *Call {
+	return c.Times(0)
+}
+
 // SetArg declares an action that will set the nth argument's value,
 // indirected through a pointer. Or, in the case of a slice and map, SetArg
 // will copy value's elements/key-value pairs into the nth argument.
+// For variadic methods, n may index into the variadic arguments, counting
+// each of them as a separate argument.
+// If the nth parameter is an interface, the argument must hold a pointer at
+// call time, and value is assigned to what it points to.
 func (c *Call) SetArg(n int, value any) *Call {
 	c.t.Helper()
 
 	mt := c.methodType
-	// TODO: This will break on variadic methods.
-	// We will need to check those at invocation time.
-	if n < 0 || n >= mt.NumIn() {
+	var at reflect.Type
+	switch {
+	case mt.IsVariadic() && n >= mt.NumIn()-1:
+		// The number of variadic arguments is only known at invocation time.
+		at = mt.In(mt.NumIn() - 1).Elem()
+	case n < 0 || n >= mt.NumIn():
 		c.t.Fatalf("SetArg(%d, ...) called for a method with %d args [%s]",
 			n, mt.NumIn(), c.origin)
+		return c
+	default:
+		at = mt.In(n)
 	}
 	// Permit setting argument through an interface.
 	// In the interface case, we don't (nay, can't) check the type here.
-	at := mt.In(n)
 	switch at.Kind() {
 	case reflect.Ptr:
 		dt := at.Elem()
@@ -257,7 +720,18 @@ func (c *Call) SetArg(n int, value any) *Call {
 				n, vt, dt, c.origin)
 		}
 	case reflect.Interface:
-		// nothing to do
+		// The pointer's type is only known at call time, unless the argument
+		// was recorded as a value to compare against.
+		if n < len(c.args) {
+			if em, ok := c.args[n].(eqMatcher); ok {
+				if pt := reflect.TypeOf(em.x); pt != nil && pt.Kind() == reflect.Ptr {
+					if vt := reflect.TypeOf(value); vt != nil && !vt.AssignableTo(pt.Elem()) {
+						c.t.Fatalf("SetArg(%d, ...) argument is a %v, not assignable to %v [%s]",
+							n, vt, pt.Elem(), c.origin)
+					}
+				}
+			}
+		}
 	case reflect.Slice:
 		// nothing to do
 	case reflect.Map:
@@ -268,15 +742,117 @@ func (c *Call) SetArg(n int, value any) *Call {
 	}
 
 	c.addAction(func(args []any) []any {
-		v := reflect.ValueOf(value)
-		switch reflect.TypeOf(args[n]).Kind() {
-		case reflect.Slice:
-			setSlice(args[n], v)
-		case reflect.Map:
-			setMap(args[n], v)
-		default:
-			reflect.ValueOf(args[n]).Elem().Set(v)
+		c.t.Helper()
+		c.setArg("SetArg", n, args, value)
+		return nil
+	})
+	return c
+}
+
+// SetArgFunc is like SetArg, but the value to set is computed by calling fn
+// with the actual arguments of each call. This allows out-parameters that
+// depend on the inputs, e.g. for a method Sum(a, b int, out *int):
+//
+//	m.EXPECT().Sum(gomock.Any(), gomock.Any(), gomock.Any()).SetArgFunc(2, func(args []any) any {
+//	  return args[0].(int) + args[1].(int)
+//	})
+//
+// Since the value is only known at call time, it is checked then to be
+// assignable to the argument, and the test fails if it isn't.
+func (c *Call) SetArgFunc(n int, fn func(args []any) any) *Call {
+	c.t.Helper()
+
+	mt := c.methodType
+	if n < 0 || (!mt.IsVariadic() && n >= mt.NumIn()) {
+		c.t.Fatalf("SetArgFunc(%d, ...) called for a method with %d args [%s]",
+			n, mt.NumIn(), c.origin)
+		return c
+	}
+
+	c.addAction(func(args []any) []any {
+		c.t.Helper()
+		c.setArg("SetArgFunc", n, args, fn(args))
+		return nil
+	})
+	return c
+}
+
+// setArg sets the nth of args to value for SetArg and similar actions, named
+// name in failure messages.
+func (c *Call) setArg(name string, n int, args []any, value any) {
+	c.t.Helper()
+	if n >= len(args) {
+		c.t.Fatalf("%s(%d, ...) called for a call of %T.%v with %d args [%s]",
+			name, n, c.receiver, c.method, len(args), c.origin)
+		return
+	}
+	v := reflect.ValueOf(value)
+	av := reflect.ValueOf(args[n])
+	switch av.Kind() {
+	case reflect.Slice:
+		setSlice(args[n], v)
+	case reflect.Map:
+		setMap(args[n], v)
+	case reflect.Ptr:
+		if av.IsNil() {
+			c.t.Fatalf("%s(%d, ...) called with a nil %T argument [%s]", name, n, args[n], c.origin)
+			return
+		}
+		dt := av.Type().Elem()
+		if !v.IsValid() {
+			v = reflect.Zero(dt)
 		}
+		if !v.Type().AssignableTo(dt) {
+			c.t.Fatalf("%s(%d, ...) argument is a %v, not assignable to %v [%s]",
+				name, n, v.Type(), dt, c.origin)
+			return
+		}
+		av.Elem().Set(v)
+	default:
+		c.t.Fatalf("%s(%d, ...) called with a %T argument, which is not a pointer, slice or map [%s]",
+			name, n, args[n], c.origin)
+	}
+}
+
+// SetArgError declares an action that will set the nth argument, which must
+// be an *error or an interface holding one at call time, to err. Unlike
+// SetArg, err may be nil, in which case the error is cleared.
+func (c *Call) SetArgError(n int, err error) *Call {
+	c.t.Helper()
+
+	errType := reflect.TypeOf((*error)(nil)).Elem()
+	mt := c.methodType
+	var at reflect.Type
+	switch {
+	case mt.IsVariadic() && n >= mt.NumIn()-1:
+		at = mt.In(mt.NumIn() - 1).Elem()
+	case n < 0 || n >= mt.NumIn():
+		c.t.Fatalf("SetArgError(%d, ...) called for a method with %d args [%s]",
+			n, mt.NumIn(), c.origin)
+		return c
+	default:
+		at = mt.In(n)
+	}
+	if at.Kind() != reflect.Interface && (at.Kind() != reflect.Ptr || at.Elem() != errType) {
+		c.t.Fatalf("SetArgError(%d, ...) referring to argument of type %v, which is not *error or an interface [%s]",
+			n, at, c.origin)
+		return c
+	}
+
+	c.addAction(func(args []any) []any {
+		c.t.Helper()
+		if n >= len(args) {
+			c.t.Fatalf("SetArgError(%d, ...) called for a call of %T.%v with %d args [%s]",
+				n, c.receiver, c.method, len(args), c.origin)
+			return nil
+		}
+		ep, ok := args[n].(*error)
+		if !ok || ep == nil {
+			c.t.Fatalf("SetArgError(%d, ...) called with a %T argument, which is not a non-nil *error [%s]",
+				n, args[n], c.origin)
+			return nil
+		}
+		*ep = err
 		return nil
 	})
 	return c
@@ -295,28 +871,86 @@ func (c *Call) isPreReq(other *Call) bool {
 // After declares that the call may only match after preReq has been exhausted.
 func (c *Call) After(preReq *Call) *Call {
 	c.t.Helper()
+	return c.AfterAll(preReq)
+}
 
-	if c == preReq {
-		c.t.Fatalf("A call isn't allowed to be its own prerequisite")
-	}
-	if preReq.isPreReq(c) {
-		c.t.Fatalf("Loop in call order: %v is a prerequisite to %v (possibly indirectly).", c, preReq)
+// AfterAll declares that the call may only match after all of preReqs have
+// been exhausted. It is equivalent to calling After for each of them, except
+// that no prerequisite is added if any of them would create a loop.
+func (c *Call) AfterAll(preReqs ...*Call) *Call {
+	c.t.Helper()
+
+	for _, preReq := range preReqs {
+		if c == preReq {
+			c.t.Fatalf("A call isn't allowed to be its own prerequisite")
+		}
+		if preReq.isPreReq(c) {
+			c.t.Fatalf("Loop in call order: %v is a prerequisite to %v (possibly indirectly).", c, preReq)
+		}
 	}
 
-	c.preReqs = append(c.preReqs, preReq)
+	c.preReqs = append(c.preReqs, preReqs...)
 	return c
 }
 
 // Returns true if the minimum number of calls have been made.
 func (c *Call) satisfied() bool {
+	if c.countOK != nil {
+		return c.countOK(c.numCalls)
+	}
 	return c.numCalls >= c.minCalls
 }
 
+// Returns true if the call belongs to an AllOrNone group none of whose calls
+// were made, so that it doesn't need to be satisfied.
+func (c *Call) skippedGroup() bool {
+	if c.group == nil {
+		return false
+	}
+	for _, call := range c.group {
+		if call.numCalls > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns true if the call has no practical maximum number of calls.
+func (c *Call) unbounded() bool {
+	return c.maxCalls >= 1e8
+}
+
 // Returns true if the maximum number of calls have been made.
 func (c *Call) exhausted() bool {
 	return c.numCalls >= c.maxCalls
 }
 
+// Method returns the name of the method the call is expected on.
+func (c *Call) Method() string {
+	return c.method
+}
+
+// MinCalls returns the minimum number of times the call is expected to be
+// made.
+func (c *Call) MinCalls() int {
+	return c.minCalls
+}
+
+// MaxCalls returns the maximum number of times the call may be made. Calls
+// set up with AnyTimes or MinTimes report a very large number rather than an
+// actual bound.
+func (c *Call) MaxCalls() int {
+	return c.maxCalls
+}
+
+// NumCalls returns the number of times the call has been made so far. It is
+// safe to call while the mock is being used from other goroutines.
+func (c *Call) NumCalls() int {
+	c.countMu.Lock()
+	defer c.countMu.Unlock()
+	return c.numCalls
+}
+
 func (c *Call) String() string {
 	args := make([]string, len(c.args))
 	for i, arg := range c.args {
@@ -326,11 +960,63 @@ func (c *Call) String() string {
 	return fmt.Sprintf("%T.%v(%s) %s", c.receiver, c.method, arguments, c.origin)
 }
 
-// Tests if the given call matches the expected call.
-// If yes, returns nil. If no, returns error with message explaining why it does not match.
+// matches returns an error explaining why args don't match c, or nil if they
+// do.
 func (c *Call) matches(args []any) error {
+	return c.match(args, true)
+}
+
+// truncateDiff shortens diff to at most n runes, marking it as truncated.
+// It returns diff unchanged if n <= 0.
+func truncateDiff(diff string, n int) string {
+	if n <= 0 || utf8.RuneCountInString(diff) <= n {
+		return diff
+	}
+	runes := []rune(diff)
+	return string(runes[:n]) + "… (truncated)"
+}
+
+// match is like matches, but only formats a descriptive error if explain is
+// set. Otherwise errMismatch is returned, which avoids the cost of formatting
+// arguments and diffs when searching many expected calls for a match.
+func (c *Call) match(args []any, explain bool) error {
+	// Methods without arguments, such as Close, have nothing to match.
+	if len(args) != 0 || len(c.args) != 0 {
+		if err := c.matchArgs(args, explain); err != nil {
+			return err
+		}
+	}
+
+	// Check that all prerequisite calls have been satisfied.
+	for _, preReqCall := range c.preReqs {
+		if !preReqCall.satisfied() {
+			if !explain {
+				return errMismatch
+			}
+			return fmt.Errorf("expected call at %s doesn't have a prerequisite call satisfied:\n%v\nshould be called before:\n%v",
+				c.origin, preReqCall, c)
+		}
+	}
+
+	// Check that the call is not exhausted.
+	if c.exhausted() {
+		if !explain {
+			return errMismatch
+		}
+		return fmt.Errorf("expected call at %s has already been called the max number of times", c.origin)
+	}
+
+	return nil
+}
+
+// matchArgs returns an error if args don't match the argument matchers of c.
+// As for match, the error only explains the mismatch if explain is set.
+func (c *Call) matchArgs(args []any, explain bool) error {
 	if !c.methodType.IsVariadic() {
 		if len(args) != len(c.args) {
+			if !explain {
+				return errMismatch
+			}
 			return fmt.Errorf("expected call at %s has the wrong number of arguments. Got: %d, want: %d",
 				c.origin, len(args), len(c.args))
 		}
@@ -338,6 +1024,9 @@ func (c *Call) matches(args []any) error {
 		for i, m := range c.args {
 			arg := args[i]
 			if !m.Matches(arg) {
+				if !explain {
+					return errMismatch
+				}
 				var sb strings.Builder
 				sb.WriteString(
 					fmt.Sprintf("expected call at %s doesn't match the argument at index %d.", c.origin, i),
@@ -348,8 +1037,14 @@ func (c *Call) matches(args []any) error {
 						c.origin, i, g.Got(arg), m,
 					)
 				}
-				if d, ok := m.(Differ); ok {
-					diff := d.Diff(arg, c.cmpOpts...)
+				if em, ok := m.(eqMatcher); ok && c.reflectEqual {
+					return fmt.Errorf(
+						"expected call at %s doesn't match the argument at index %d.\nDiff (-want +got):\n-%+v\n+%+v",
+						c.origin, i, em.x, arg,
+					)
+				}
+				if d, ok := m.(Differ); ok && !c.reflectEqual {
+					diff := truncateDiff(d.Diff(arg, c.cmpOpts...), c.maxDiffLen)
 					return fmt.Errorf(
 						"expected call at %s doesn't match the argument at index %d.\nDiff (-want +got): %s",
 						c.origin, i, diff,
@@ -363,14 +1058,23 @@ func (c *Call) matches(args []any) error {
 		}
 	} else {
 		if len(c.args) < c.methodType.NumIn()-1 {
+			if !explain {
+				return errMismatch
+			}
 			return fmt.Errorf("expected call at %s has the wrong number of matchers. Got: %d, want: %d",
 				c.origin, len(c.args), c.methodType.NumIn()-1)
 		}
 		if len(c.args) != c.methodType.NumIn() && len(args) != len(c.args) {
+			if !explain {
+				return errMismatch
+			}
 			return fmt.Errorf("expected call at %s has the wrong number of arguments. Got: %d, want: %d",
 				c.origin, len(args), len(c.args))
 		}
 		if len(args) < len(c.args)-1 {
+			if !explain {
+				return errMismatch
+			}
 			return fmt.Errorf("expected call at %s has the wrong number of arguments. Got: %d, want: greater than or equal to %d",
 				c.origin, len(args), len(c.args)-1)
 		}
@@ -379,6 +1083,9 @@ func (c *Call) matches(args []any) error {
 			if i < c.methodType.NumIn()-1 {
 				// Non-variadic args
 				if !m.Matches(args[i]) {
+					if !explain {
+						return errMismatch
+					}
 					return fmt.Errorf("expected call at %s doesn't match the argument at index %s.\nGot: %v\nWant: %v",
 						c.origin, strconv.Itoa(i), formatGottenArg(m, args[i]), m)
 				}
@@ -423,25 +1130,43 @@ func (c *Call) matches(args []any) error {
 			// Got Foo(a, b, c, d, e) want Foo(matcherA, matcherB, matcherC, matcherD)
 			// Got Foo(a, b, c) want Foo(matcherA, matcherB)
 
+			if !explain {
+				return errMismatch
+			}
 			return fmt.Errorf("expected call at %s doesn't match the argument at index %s.\nGot: %v\nWant: %v",
 				c.origin, strconv.Itoa(i), formatGottenArg(m, args[i:]), c.args[i])
 		}
 	}
+	return nil
+}
 
-	// Check that all prerequisite calls have been satisfied.
-	for _, preReqCall := range c.preReqs {
-		if !preReqCall.satisfied() {
-			return fmt.Errorf("expected call at %s doesn't have a prerequisite call satisfied:\n%v\nshould be called before:\n%v",
-				c.origin, preReqCall, c)
+// wildcards returns the number of argument matchers that match anything.
+func (c *Call) wildcards() int {
+	n := 0
+	for _, m := range c.args {
+		if _, ok := m.(anyMatcher); ok {
+			n++
 		}
 	}
+	return n
+}
 
-	// Check that the call is not exhausted.
-	if c.exhausted() {
-		return fmt.Errorf("expected call at %s has already been called the max number of times", c.origin)
+// mismatchedArg returns the index of the first argument that doesn't match
+// its matcher, or -1 if there is none or the number of arguments differs.
+// The trailing argument of a variadic method is not checked.
+func (c *Call) mismatchedArg(args []any) int {
+	n := len(c.args)
+	if c.methodType.IsVariadic() {
+		n = c.methodType.NumIn() - 1
+	} else if len(args) != n {
+		return -1
 	}
-
-	return nil
+	for i := 0; i < n && i < len(args) && i < len(c.args); i++ {
+		if !c.args[i].Matches(args[i]) {
+			return i
+		}
+	}
+	return -1
 }
 
 // dropPrereqs tells the expected Call to not re-check prerequisite calls any
@@ -453,8 +1178,29 @@ func (c *Call) dropPrereqs() (preReqs []*Call) {
 }
 
 func (c *Call) call() []func([]any) []any {
+	c.countMu.Lock()
 	c.numCalls++
-	return c.actions
+	c.countMu.Unlock()
+	if len(c.overrides) == 0 {
+		return c.actions
+	}
+	overrides, actions := c.overrides, c.actions
+	return []func([]any) []any{func(args []any) []any {
+		c.t.Helper()
+		for _, fn := range overrides {
+			if rets, handled := fn(args); handled {
+				c.checkReturnValues("DoOrReturn", rets)
+				return rets
+			}
+		}
+		var rets []any
+		for _, action := range actions {
+			if r := action(args); r != nil {
+				rets = r
+			}
+		}
+		return rets
+	}}
 }
 
 // InOrder declares that the given calls should occur in order.
@@ -478,6 +1224,37 @@ func InOrder(args ...any) {
 	}
 }
 
+// AllOrNone declares that the given calls form a group that is made either
+// entirely or not at all: at Finish, either every call in the group has been
+// made its minimum number of times, or none of them has been made. A group
+// that was partially made is reported as missing the calls not yet satisfied.
+// This models transaction-like interactions, such as Begin, Exec and Commit,
+// that a test may or may not trigger.
+//
+// The calls should be recorded on the same Controller. It panics if the type
+// of any of the arguments isn't *Call or a generated mock with an embedded
+// *Call, or if a call already belongs to another group.
+func AllOrNone(args ...any) {
+	calls := make([]*Call, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		call := getCall(args[i])
+		if call == nil {
+			panic(fmt.Sprintf(
+				"invalid argument at position %d of type %T, AllOrNone expects *gomock.Call or generated mock types with an embedded *gomock.Call",
+				i,
+				args[i],
+			))
+		}
+		if call.group != nil {
+			panic(fmt.Sprintf("%v already belongs to an AllOrNone group", call))
+		}
+		calls = append(calls, call)
+	}
+	for _, call := range calls {
+		call.group = calls
+	}
+}
+
 // getCall checks if the parameter is a *Call or a generated struct
 // that wraps a *Call and returns the *Call pointer - if neither, it returns nil.
 func getCall(arg any) *Call {
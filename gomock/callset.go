@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"math/rand"
 	"sync"
 )
 
@@ -31,6 +32,12 @@ type callSet struct {
 	exhausted map[callSetKey][]*Call
 	// when set to true, existing call expectations are overridden when new call expectations are made
 	allowOverride bool
+	// when set to true, the matching call with the fewest Any() matchers is
+	// selected instead of the first one
+	preferSpecific bool
+	// if set, expected calls are tried in an order shuffled by shuffle
+	// rather than the order they were added in
+	shuffle *rand.Rand
 }
 
 // callSetKey is the key in the maps in callSet
@@ -92,6 +99,41 @@ func (cs callSet) Remove(call *Call) {
 	}
 }
 
+// Reset removes all expected and exhausted calls.
+func (cs callSet) Reset() {
+	cs.expectedMu.Lock()
+	defer cs.expectedMu.Unlock()
+
+	for key := range cs.expected {
+		delete(cs.expected, key)
+	}
+	for key := range cs.exhausted {
+		delete(cs.exhausted, key)
+	}
+}
+
+// Has reports whether any call, expected or exhausted, was added for the
+// method of receiver.
+func (cs callSet) Has(receiver any, method string) bool {
+	key := callSetKey{receiver, method}
+
+	cs.expectedMu.Lock()
+	defer cs.expectedMu.Unlock()
+
+	return len(cs.expected[key])+len(cs.exhausted[key]) > 0
+}
+
+// NumExpected returns the number of calls added for the method of receiver
+// that are not yet exhausted.
+func (cs callSet) NumExpected(receiver any, method string) int {
+	key := callSetKey{receiver, method}
+
+	cs.expectedMu.Lock()
+	defer cs.expectedMu.Unlock()
+
+	return len(cs.expected[key])
+}
+
 // FindMatch searches for a matching call. Returns error with explanation message if no call matched.
 func (cs callSet) FindMatch(receiver any, method string, args []any) (*Call, error) {
 	key := callSetKey{receiver, method}
@@ -99,16 +141,34 @@ func (cs callSet) FindMatch(receiver any, method string, args []any) (*Call, err
 	cs.expectedMu.Lock()
 	defer cs.expectedMu.Unlock()
 
-	// Search through the expected calls.
+	// Search through the expected calls. Explanations are only needed if
+	// nothing matches, so skip formatting them on this first pass.
 	expected := cs.expected[key]
-	var callsErrors bytes.Buffer
-	for _, call := range expected {
-		err := call.matches(args)
-		if err != nil {
-			_, _ = fmt.Fprintf(&callsErrors, "\n%v", err)
-		} else {
+	candidates := expected
+	if cs.shuffle != nil {
+		candidates = make([]*Call, len(expected))
+		for i, j := range cs.shuffle.Perm(len(expected)) {
+			candidates[i] = expected[j]
+		}
+	}
+	var best *Call
+	for _, call := range candidates {
+		if call.match(args, false) != nil {
+			continue
+		}
+		if !cs.preferSpecific {
 			return call, nil
 		}
+		if best == nil || call.wildcards() < best.wildcards() {
+			best = call
+		}
+	}
+	if best != nil {
+		return best, nil
+	}
+	var callsErrors bytes.Buffer
+	for _, call := range expected {
+		_, _ = fmt.Fprintf(&callsErrors, "\n%v", call.matches(args))
 	}
 
 	// If we haven't found a match then search through the exhausted calls so we
@@ -131,6 +191,31 @@ func (cs callSet) FindMatch(receiver any, method string, args []any) (*Call, err
 	return nil, errors.New(callsErrors.String())
 }
 
+// describeMismatch returns a Failure for a call that FindMatch didn't match,
+// pointing at the first mismatching argument of the first expected call, or
+// of the first exhausted call if none are expected.
+func (cs callSet) describeMismatch(receiver any, method string, args []any) Failure {
+	key := callSetKey{receiver, method}
+
+	cs.expectedMu.Lock()
+	defer cs.expectedMu.Unlock()
+
+	f := Failure{Receiver: receiver, Method: method, Args: args, ArgIndex: -1}
+	calls := cs.expected[key]
+	if len(calls) == 0 {
+		calls = cs.exhausted[key]
+	}
+	if len(calls) == 0 {
+		return f
+	}
+	if i := calls[0].mismatchedArg(args); i >= 0 {
+		f.ArgIndex = i
+		f.Want = calls[0].args[i].String()
+		f.Got = args[i]
+	}
+	return f
+}
+
 // Failures returns the calls that are not satisfied.
 func (cs callSet) Failures() []*Call {
 	cs.expectedMu.Lock()
@@ -139,7 +224,7 @@ func (cs callSet) Failures() []*Call {
 	failures := make([]*Call, 0, len(cs.expected))
 	for _, calls := range cs.expected {
 		for _, call := range calls {
-			if !call.satisfied() {
+			if !call.satisfied() && !call.skippedGroup() {
 				failures = append(failures, call)
 			}
 		}
@@ -154,7 +239,7 @@ func (cs callSet) Satisfied() bool {
 
 	for _, calls := range cs.expected {
 		for _, call := range calls {
-			if !call.satisfied() {
+			if !call.satisfied() && !call.skippedGroup() {
 				return false
 			}
 		}
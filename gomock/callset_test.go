@@ -16,6 +16,7 @@ package gomock
 
 import (
 	"reflect"
+	"strconv"
 	"testing"
 )
 
@@ -115,3 +116,29 @@ func TestCallSetFindMatch(t *testing.T) {
 		}
 	})
 }
+
+type keyedReceiverType struct{}
+
+func (keyedReceiverType) Func(key string, value int) {}
+
+func BenchmarkCallSetFindMatch(b *testing.B) {
+	for _, n := range []int{1, 10, 1000} {
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			cs := newCallSet()
+			var receiver any = "TestReceiver"
+			method := "TestMethod"
+			for i := 0; i < n; i++ {
+				c := newCall(b, receiver, method, reflect.TypeOf(keyedReceiverType{}.Func), nil, "key"+strconv.Itoa(i), i)
+				cs.Add(c.AnyTimes())
+			}
+			args := []any{"key" + strconv.Itoa(n-1), n - 1}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := cs.FindMatch(receiver, method, args); err != nil {
+					b.Fatalf("FindMatch: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -16,14 +16,22 @@ package gomock
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand"
 	"reflect"
 	"runtime"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
 
+// ErrNotMocked is returned by mocks generated with -default_return=error from
+// methods that have an error result but no expected calls.
+var ErrNotMocked = errors.New("gomock: method has no expected calls")
+
 // A TestReporter is something that can be used to report test failures.  It
 // is satisfied by the standard library's *testing.T.
 type TestReporter interface {
@@ -47,10 +55,42 @@ type cleanuper interface {
 	Cleanup(func())
 }
 
+// Failure describes an unexpected call to a mock, as passed to
+// StructuredReporter.ReportFailure.
+type Failure struct {
+	Receiver any    // the receiver of the method call
+	Method   string // the name of the method
+	Args     []any  // the arguments of the call
+	// ArgIndex is the index of the first argument that didn't match the
+	// closest expected call, or -1 if the failure isn't about a single
+	// argument, e.g. because there are no expected calls for the method.
+	ArgIndex int
+	Want     string // the description of the matcher at ArgIndex
+	Got      any    // the argument at ArgIndex
+	Message  string // the message also passed to Fatalf
+}
+
+// StructuredReporter is an optional interface a TestReporter can implement
+// to receive failures as structured data. ReportFailure is called for every
+// unexpected call, before the failure is reported through Fatalf.
+type StructuredReporter interface {
+	ReportFailure(Failure)
+}
+
+// logger is implemented by TestReporters that can log messages, such as
+// *testing.T.
+type logger interface {
+	Logf(format string, args ...any)
+}
+
 // A Controller represents the top-level control of a mock ecosystem.  It
 // defines the scope and lifetime of mock objects, as well as their
 // expectations.  It is safe to call Controller's methods from multiple
-// goroutines. Each test should create a new Controller and invoke Finish via
+// goroutines. This includes recording expectations, e.g. with EXPECT from
+// setup helpers running concurrently, as each recording holds the
+// Controller's lock only briefly. A *Call must however be configured, e.g.
+// with Return or Times, by the goroutine that recorded it, before the mock
+// is called. Each test should create a new Controller and invoke Finish via
 // defer.
 //
 //	func TestFoo(t *testing.T) {
@@ -73,12 +113,43 @@ type Controller struct {
 	// be used in user code and may be changed in future versions. T is the
 	// TestReporter passed in when creating the Controller via NewController.
 	// If the TestReporter does not implement a TestHelper it will be wrapped
-	// with a nopTestHelper.
+	// with a nopTestHelper. Use Reporter to get the TestReporter itself.
 	T             TestHelper
 	mu            sync.Mutex
 	expectedCalls *callSet
 	finished      bool
 	cmpOpts       cmp.Options
+	reflectEqual  bool
+	// maxDiffLen, if positive, limits the length in runes of argument diffs
+	// in failure messages.
+	maxDiffLen int
+	// orderingWarnings logs a warning when a call with unbounded prerequisites
+	// is matched.
+	orderingWarnings bool
+	// matchSpecificity selects the matching call with the fewest Any()
+	// matchers rather than the first recorded one.
+	matchSpecificity bool
+	// shuffleMatches tries expected calls in an order shuffled with a
+	// source seeded with shuffleSeed.
+	shuffleMatches bool
+	shuffleSeed    int64
+	observer       func(CallInfo)
+	// deferFailures records unexpected calls in unexpected, to be reported
+	// by Finish, instead of failing the test right away.
+	deferFailures bool
+	unexpected    []string
+	// registerCleanup, if set, is used instead of the TestReporter's Cleanup
+	// method to have the Controller finished at the end of the test.
+	registerCleanup func(func())
+	// strictOrdering chains every recorded call after lastRecorded.
+	strictOrdering bool
+	lastRecorded   *Call
+	// argTransformer, if set, is applied to received arguments before they
+	// are matched, and to arguments recorded as plain values.
+	argTransformer func(any) any
+	// children are the Controllers created with NewChild, which are
+	// finished along with this one.
+	children []*Controller
 }
 
 // NewController returns a new Controller. It is the preferred way to create a Controller.
@@ -94,11 +165,25 @@ func NewController(t TestReporter, opts ...ControllerOption) *Controller {
 		T:             h,
 		expectedCalls: newCallSet(),
 	}
+	defaultOptionsMu.RLock()
+	defaults := defaultOptions
+	defaultOptionsMu.RUnlock()
+	for _, opt := range defaults {
+		opt.apply(ctrl)
+	}
 	for _, opt := range opts {
 		opt.apply(ctrl)
 	}
-	if c, ok := isCleanuper(ctrl.T); ok {
-		c.Cleanup(func() {
+	ctrl.expectedCalls.preferSpecific = ctrl.matchSpecificity
+	if ctrl.shuffleMatches {
+		ctrl.expectedCalls.shuffle = rand.New(rand.NewSource(ctrl.shuffleSeed))
+	}
+	register := ctrl.registerCleanup
+	if c, ok := isCleanuper(ctrl.T); ok && register == nil {
+		register = c.Cleanup
+	}
+	if register != nil {
+		register(func() {
 			ctrl.T.Helper()
 			ctrl.finish(true, nil)
 		})
@@ -107,11 +192,64 @@ func NewController(t TestReporter, opts ...ControllerOption) *Controller {
 	return ctrl
 }
 
+// NewChild returns a Controller that reports to the same TestReporter and is
+// configured with the same options as ctrl, but has its own set of expected
+// calls. Calls recorded on the child must be made on the child, and its
+// Finish only checks those calls, so that a test helper can verify its own
+// mocks independently of the test's.
+//
+// Finishing ctrl also finishes any child that hasn't been finished yet. The
+// child's missing calls are then reported with Errorf rather than Fatalf,
+// before ctrl's own expectations are checked.
+func (ctrl *Controller) NewChild() *Controller {
+	ctrl.mu.Lock()
+	defer ctrl.mu.Unlock()
+
+	child := &Controller{
+		T:                ctrl.T,
+		expectedCalls:    newCallSet(),
+		cmpOpts:          ctrl.cmpOpts,
+		reflectEqual:     ctrl.reflectEqual,
+		maxDiffLen:       ctrl.maxDiffLen,
+		orderingWarnings: ctrl.orderingWarnings,
+		matchSpecificity: ctrl.matchSpecificity,
+		shuffleMatches:   ctrl.shuffleMatches,
+		shuffleSeed:      ctrl.shuffleSeed,
+		observer:         ctrl.observer,
+		deferFailures:    ctrl.deferFailures,
+		strictOrdering:   ctrl.strictOrdering,
+		argTransformer:   ctrl.argTransformer,
+	}
+	child.expectedCalls.preferSpecific = child.matchSpecificity
+	if child.shuffleMatches {
+		child.expectedCalls.shuffle = rand.New(rand.NewSource(child.shuffleSeed))
+	}
+	ctrl.children = append(ctrl.children, child)
+	return child
+}
+
 // ControllerOption configures how a Controller should behave.
 type ControllerOption interface {
 	apply(*Controller)
 }
 
+var (
+	defaultOptionsMu sync.RWMutex
+	defaultOptions   []ControllerOption
+)
+
+// SetDefaultControllerOptions sets options that NewController applies to
+// every Controller before the options passed to it, so that explicitly
+// passed options take precedence. Each call replaces the previously set
+// defaults; calling it without options removes them. It is safe to call
+// concurrently with NewController, but is typically called once from
+// TestMain.
+func SetDefaultControllerOptions(opts ...ControllerOption) {
+	defaultOptionsMu.Lock()
+	defer defaultOptionsMu.Unlock()
+	defaultOptions = append([]ControllerOption(nil), opts...)
+}
+
 type overridableExpectationsOption struct{}
 
 // WithOverridableExpectations allows for overridable call expectations
@@ -138,6 +276,186 @@ func WithCmpOpts(opts ...cmp.Option) cmpOptions {
 	return cmpOptions{opts: opts}
 }
 
+type strictOrderingOption struct{}
+
+type reflectEqualOption struct{}
+
+func (reflectEqualOption) apply(ctrl *Controller) {
+	ctrl.reflectEqual = true
+}
+
+// WithReflectEqual is a ControllerOption that stops failure messages from
+// using go-cmp to diff arguments against matchers that implement Differ,
+// such as Eq. The got and want values are printed with %+v instead. This is
+// an escape hatch for types go-cmp panics on, e.g. because of unexported
+// fields. Eq compares with reflect.DeepEqual regardless of this option;
+// matchers that are explicitly built on go-cmp, like EqWithOpts, still use it.
+func WithReflectEqual() reflectEqualOption {
+	return reflectEqualOption{}
+}
+
+type maxDiffLenOption struct {
+	n int
+}
+
+func (o maxDiffLenOption) apply(ctrl *Controller) {
+	ctrl.maxDiffLen = o.n
+}
+
+// WithMaxDiffLen is a ControllerOption that truncates the diffs of mismatched
+// arguments in failure messages to n runes, followed by "… (truncated)", to
+// keep huge values from flooding test logs. Matching is not affected. A
+// non-positive n leaves diffs untruncated, which is the default.
+func WithMaxDiffLen(n int) maxDiffLenOption {
+	return maxDiffLenOption{n}
+}
+
+type argTransformerOption struct {
+	fn func(any) any
+}
+
+func (o argTransformerOption) apply(ctrl *Controller) {
+	ctrl.argTransformer = o.fn
+}
+
+// WithArgTransformer is a ControllerOption that normalizes arguments with fn,
+// e.g. to trim whitespace from strings, instead of using custom matchers
+// everywhere:
+//
+//   - When a call is made, fn is applied to each of its arguments, and the
+//     results are matched against the expected calls. They are also what
+//     failure messages show as the received values.
+//   - When a call is recorded, fn is applied to each argument that is a plain
+//     value rather than a Matcher, before it is wrapped with Eq. Matchers
+//     only see the transformed received values.
+//
+// Actions such as Do, DoAndReturn and SetArg still get the original
+// arguments, so fn should return arguments it doesn't normalize unchanged.
+func WithArgTransformer(fn func(arg any) any) argTransformerOption {
+	return argTransformerOption{fn}
+}
+
+type matchSpecificityOption struct{}
+
+func (matchSpecificityOption) apply(ctrl *Controller) {
+	ctrl.matchSpecificity = true
+}
+
+// WithMatchSpecificity is a ControllerOption that changes which expected call
+// is used when several of them match a call. By default, the one recorded
+// first is used. With this option, the one with the fewest Any() argument
+// matchers is used instead, and ties are broken by recording order. This
+// lets a catch-all expectation be recorded before more specific ones.
+func WithMatchSpecificity() matchSpecificityOption {
+	return matchSpecificityOption{}
+}
+
+type matchShuffleOption struct {
+	seed int64
+}
+
+func (o matchShuffleOption) apply(ctrl *Controller) {
+	ctrl.shuffleMatches = true
+	ctrl.shuffleSeed = o.seed
+}
+
+// WithMatchShuffle is a ControllerOption that makes the Controller try the
+// expected calls of a method in a random order, derived from seed, when
+// looking for one that matches a call, instead of the order in which they
+// were recorded. Running a test with different seeds reveals whether it
+// accidentally depends on that order, e.g. when several expectations match
+// the same arguments. Orderings declared with InOrder, After or
+// WithStrictOrdering still hold.
+func WithMatchShuffle(seed int64) matchShuffleOption {
+	return matchShuffleOption{seed}
+}
+
+type orderingWarningsOption struct{}
+
+func (orderingWarningsOption) apply(ctrl *Controller) {
+	ctrl.orderingWarnings = true
+}
+
+// WithOrderingWarnings is a ControllerOption that logs a warning through the
+// TestReporter's Logf method, if it has one, whenever a call is matched whose
+// prerequisite, e.g. from InOrder or After, has no maximum number of calls,
+// such as one declared with AnyTimes or MinTimes. Such a prerequisite stops
+// being expected as soon as the later call is made, which is often not what
+// was intended.
+func WithOrderingWarnings() orderingWarningsOption {
+	return orderingWarningsOption{}
+}
+
+type deferredFailuresOption struct{}
+
+func (deferredFailuresOption) apply(ctrl *Controller) {
+	ctrl.deferFailures = true
+}
+
+// WithDeferredFailures is a ControllerOption that makes unexpected calls
+// return zero values instead of failing the test immediately. All of them
+// are reported together by Finish. This is useful while writing a test, to
+// find out which calls the code under test makes.
+func WithDeferredFailures() deferredFailuresOption {
+	return deferredFailuresOption{}
+}
+
+type forcedCleanupOption struct {
+	register func(func())
+}
+
+func (o forcedCleanupOption) apply(ctrl *Controller) {
+	ctrl.registerCleanup = o.register
+}
+
+// WithForcedCleanup is a ControllerOption that makes the Controller check for
+// missing calls, as Finish does, in a function passed to register. It is
+// meant for test frameworks whose TestReporter has no Cleanup method; with
+// one, NewController already does this through Cleanup, and register is
+// used instead of it.
+func WithForcedCleanup(register func(func())) forcedCleanupOption {
+	return forcedCleanupOption{register: register}
+}
+
+// WithStrictOrdering is a ControllerOption that requires all calls to occur
+// in the order their expectations were recorded, as if every recorded call
+// had been passed to a single InOrder.
+func WithStrictOrdering() strictOrderingOption {
+	return strictOrderingOption{}
+}
+
+func (o strictOrderingOption) apply(ctrl *Controller) {
+	ctrl.strictOrdering = true
+}
+
+// CallInfo describes a call made to a mock, as passed to the function
+// registered with WithObserver.
+type CallInfo struct {
+	Receiver any    // the receiver of the method call
+	Method   string // the name of the method
+	Args     []any  // the arguments of the call
+	// Call is the expected call that matched, or nil if the call was
+	// unexpected.
+	Call *Call
+}
+
+type observerOption struct {
+	fn func(CallInfo)
+}
+
+func (o observerOption) apply(ctrl *Controller) {
+	ctrl.observer = o.fn
+}
+
+// WithObserver is a ControllerOption that registers fn to be invoked on every
+// call made to a mock created with the Controller, whether or not the call
+// matched an expectation. fn is invoked without holding any Controller locks,
+// before the actions of the matched call run and before an unexpected call
+// is reported.
+func WithObserver(fn func(CallInfo)) observerOption {
+	return observerOption{fn: fn}
+}
+
 type cancelReporter struct {
 	t      TestHelper
 	cancel func()
@@ -180,28 +498,126 @@ func (h *nopTestHelper) Fatalf(format string, args ...any) {
 
 func (h nopTestHelper) Helper() {}
 
+// Reporter returns the TestReporter the Controller was created with, e.g.
+// by NewController or WithContext, without the wrappers that T may add. It
+// allows checking whether the reporter implements additional interfaces.
+func (ctrl *Controller) Reporter() TestReporter {
+	return unwrapTestReporter(ctrl.T)
+}
+
 // RecordCall is called by a mock. It should not be called by user code.
 func (ctrl *Controller) RecordCall(receiver any, method string, args ...any) *Call {
 	ctrl.T.Helper()
 
-	recv := reflect.ValueOf(receiver)
-	for i := 0; i < recv.Type().NumMethod(); i++ {
-		if recv.Type().Method(i).Name == method {
-			return ctrl.RecordCallWithMethodType(receiver, method, recv.Method(i).Type(), args...)
-		}
+	if mt := methodType(receiver, method); mt != nil {
+		return ctrl.RecordCallWithMethodType(receiver, method, mt, args...)
 	}
 	ctrl.T.Fatalf("gomock: failed finding method %s on %T", method, receiver)
 	panic("unreachable")
 }
 
-// RecordCallWithMethodType is called by a mock. It should not be called by user code.
+// TryRecordCall is like RecordCall, but returns an error instead of failing
+// the test if receiver has no exported method named method, or if the number
+// of args doesn't fit the method's parameters. It is meant for harnesses that
+// record calls on dynamically chosen methods and want to handle bad input
+// themselves.
+func (ctrl *Controller) TryRecordCall(receiver any, method string, args ...any) (*Call, error) {
+	ctrl.T.Helper()
+
+	mt := methodType(receiver, method)
+	if mt == nil {
+		return nil, fmt.Errorf("gomock: failed finding method %s on %T", method, receiver)
+	}
+	if mt.IsVariadic() {
+		if len(args) < mt.NumIn()-1 {
+			return nil, fmt.Errorf("gomock: %T.%s takes at least %d argument(s), got %d", receiver, method, mt.NumIn()-1, len(args))
+		}
+	} else if len(args) != mt.NumIn() {
+		return nil, fmt.Errorf("gomock: %T.%s takes %d argument(s), got %d", receiver, method, mt.NumIn(), len(args))
+	}
+	return ctrl.RecordCallWithMethodType(receiver, method, mt, args...), nil
+}
+
+type methodKey struct {
+	recv   reflect.Type
+	method string
+}
+
+// methodTypes caches the results of methodType, since looking up a method
+// by reflection is expensive compared to the rest of recording a call.
+var methodTypes sync.Map // methodKey -> reflect.Type
+
+// methodType returns the type of method on receiver, without the receiver,
+// or nil if receiver is nil or has no such exported method.
+func methodType(receiver any, method string) reflect.Type {
+	if receiver == nil {
+		return nil
+	}
+	key := methodKey{reflect.TypeOf(receiver), method}
+	if mt, ok := methodTypes.Load(key); ok {
+		return mt.(reflect.Type)
+	}
+	m, ok := key.recv.MethodByName(method)
+	if !ok {
+		return nil
+	}
+	mt := m.Type
+	in := make([]reflect.Type, mt.NumIn()-1)
+	for i := range in {
+		in[i] = mt.In(i + 1)
+	}
+	out := make([]reflect.Type, mt.NumOut())
+	for i := range out {
+		out[i] = mt.Out(i)
+	}
+	ft := reflect.FuncOf(in, out, mt.IsVariadic())
+	methodTypes.Store(key, ft)
+	return ft
+}
+
+// RecordCallWithMethodType records an expected call of method on receiver,
+// whose signature is methodType. Generated mocks call it from their
+// recorders, but it is also safe to use directly, e.g. in hand-written mocks
+// or in wrappers that embed a generated mock and expose additional methods:
+//
+//	func (m *MockStoreWrapper) ExpectFlush() *gomock.Call {
+//	  return m.ctrl.RecordCallWithMethodType(m, "Flush", reflect.TypeOf(m.Flush))
+//	}
+//
+// Unlike RecordCall, the receiver doesn't need to have a method named method.
+// The matching call must then be made with Call using the same receiver and
+// method name. methodType must be a func type; it is used to validate
+// arguments and return values, so it should not include the receiver.
 func (ctrl *Controller) RecordCallWithMethodType(receiver any, method string, methodType reflect.Type, args ...any) *Call {
 	ctrl.T.Helper()
 
+	if methodType == nil || methodType.Kind() != reflect.Func {
+		ctrl.T.Fatalf("gomock: RecordCallWithMethodType for %T.%v called with non-func method type %v", receiver, method, methodType)
+		return nil
+	}
+
+	if ctrl.argTransformer != nil {
+		transformed := make([]any, len(args))
+		for i, arg := range args {
+			if _, ok := arg.(Matcher); !ok {
+				arg = ctrl.argTransformer(arg)
+			}
+			transformed[i] = arg
+		}
+		args = transformed
+	}
 	call := newCall(ctrl.T, receiver, method, methodType, ctrl.cmpOpts, args...)
+	call.reflectEqual = ctrl.reflectEqual
+	call.maxDiffLen = ctrl.maxDiffLen
 
 	ctrl.mu.Lock()
 	defer ctrl.mu.Unlock()
+	if ctrl.strictOrdering {
+		if ctrl.lastRecorded != nil {
+			call.After(ctrl.lastRecorded)
+		}
+		ctrl.lastRecorded = call
+	}
 	ctrl.expectedCalls.Add(call)
 
 	return call
@@ -211,23 +627,30 @@ func (ctrl *Controller) RecordCallWithMethodType(receiver any, method string, me
 func (ctrl *Controller) Call(receiver any, method string, args ...any) []any {
 	ctrl.T.Helper()
 
+	var failure Failure
+	var structured StructuredReporter
+	var warnings []string
+
+	matchArgs := args
+	if ctrl.argTransformer != nil {
+		matchArgs = make([]any, len(args))
+		for i, arg := range args {
+			matchArgs[i] = ctrl.argTransformer(arg)
+		}
+	}
+
 	// Nest this code so we can use defer to make sure the lock is released.
-	actions := func() []func([]any) []any {
-		ctrl.T.Helper()
+	expected, actions, err := func() (*Call, []func([]any) []any, error) {
 		ctrl.mu.Lock()
 		defer ctrl.mu.Unlock()
 
-		expected, err := ctrl.expectedCalls.FindMatch(receiver, method, args)
+		expected, err := ctrl.expectedCalls.FindMatch(receiver, method, matchArgs)
 		if err != nil {
-			// callerInfo's skip should be updated if the number of calls between the user's test
-			// and this line changes, i.e. this code is wrapped in another anonymous function.
-			// 0 is us, 1 is controller.Call(), 2 is the generated mock, and 3 is the user's test.
-			origin := callerInfo(3)
-			stringArgs := make([]string, len(args))
-			for i, arg := range args {
-				stringArgs[i] = getString(arg)
+			if sr, ok := unwrapTestReporter(ctrl.T).(StructuredReporter); ok {
+				failure = ctrl.expectedCalls.describeMismatch(receiver, method, matchArgs)
+				structured = sr
 			}
-			ctrl.T.Fatalf("Unexpected call to %T.%v(%v) at %s because: %s", receiver, method, stringArgs, origin, err)
+			return nil, nil, err
 		}
 
 		// Two things happen here:
@@ -236,15 +659,56 @@ func (ctrl *Controller) Call(receiver any, method string, args ...any) []any {
 		preReqCalls := expected.dropPrereqs()
 		for _, preReqCall := range preReqCalls {
 			ctrl.expectedCalls.Remove(preReqCall)
+			if ctrl.orderingWarnings && preReqCall.unbounded() {
+				warnings = append(warnings, fmt.Sprintf(
+					"gomock: %v has an unbounded number of calls but is a prerequisite of %v; "+
+						"it is no longer expected after the latter was called", preReqCall, expected))
+			}
 		}
 
 		actions := expected.call()
 		if expected.exhausted() {
 			ctrl.expectedCalls.Remove(expected)
 		}
-		return actions
+		return expected, actions, nil
 	}()
 
+	if len(warnings) > 0 {
+		if l, ok := unwrapTestReporter(ctrl.T).(logger); ok {
+			for _, w := range warnings {
+				l.Logf("%s", w)
+			}
+		}
+	}
+
+	if ctrl.observer != nil {
+		ctrl.observer(CallInfo{Receiver: receiver, Method: method, Args: args, Call: expected})
+	}
+
+	if err != nil {
+		// callerInfo's skip should be updated if the number of calls between the user's test
+		// and this line changes, i.e. this code is wrapped in another anonymous function.
+		// 0 is controller.Call(), 1 is the generated mock, and 2 is the user's test.
+		origin := callerInfo(2)
+		stringArgs := make([]string, len(matchArgs))
+		for i, arg := range matchArgs {
+			stringArgs[i] = getString(arg)
+		}
+		msg := fmt.Sprintf("Unexpected call to %T.%v(%v) at %s because: %s", receiver, method, stringArgs, origin, err)
+		if structured != nil {
+			failure.Message = msg
+			structured.ReportFailure(failure)
+		}
+		if ctrl.deferFailures {
+			ctrl.mu.Lock()
+			ctrl.unexpected = append(ctrl.unexpected, msg)
+			ctrl.mu.Unlock()
+			return zeroResults(receiver, method)
+		}
+		ctrl.T.Fatalf("%s", msg)
+		return nil
+	}
+
 	var rets []any
 	for _, action := range actions {
 		if r := action(args); r != nil {
@@ -255,8 +719,43 @@ func (ctrl *Controller) Call(receiver any, method string, args ...any) []any {
 	return rets
 }
 
+// zeroResults returns the zero values of the results of method on receiver,
+// or nil if receiver has no such exported method.
+func zeroResults(receiver any, method string) []any {
+	mt := methodType(receiver, method)
+	if mt == nil {
+		return nil
+	}
+	rets := make([]any, mt.NumOut())
+	for i := range rets {
+		rets[i] = reflect.Zero(mt.Out(i)).Interface()
+	}
+	return rets
+}
+
+// HasExpectedCalls reports whether any call of method on receiver has been
+// recorded, including calls that have already been made their maximum number
+// of times. It is called by mocks generated with -default_return=error and
+// should not normally be needed in user code.
+func (ctrl *Controller) HasExpectedCalls(receiver any, method string) bool {
+	ctrl.mu.Lock()
+	defer ctrl.mu.Unlock()
+	return ctrl.expectedCalls.Has(receiver, method)
+}
+
+// ExpectedCallsFor returns the number of calls recorded for method on
+// receiver that can still be matched, i.e. that have not yet been made their
+// maximum number of times.
+func (ctrl *Controller) ExpectedCallsFor(receiver any, method string) int {
+	ctrl.mu.Lock()
+	defer ctrl.mu.Unlock()
+	return ctrl.expectedCalls.NumExpected(receiver, method)
+}
+
 // Finish checks to see if all the methods that were expected to be called were called.
-// It is not idempotent and therefore can only be invoked once.
+// Only the first call reports missing calls; later calls, including the one
+// registered with Cleanup by NewController, do nothing. It is safe to call
+// Finish concurrently from multiple goroutines.
 func (ctrl *Controller) Finish() {
 	// If we're currently panicking, probably because this is a deferred call.
 	// This must be recovered in the deferred function.
@@ -264,14 +763,84 @@ func (ctrl *Controller) Finish() {
 	ctrl.finish(false, err)
 }
 
+// FinishWithTimeout is like Finish, but first waits up to d for all expected
+// calls to be satisfied. It is useful when mocks are called from background
+// goroutines that may not have run yet. FinishWithTimeout returns as soon as
+// the expectations are satisfied, or reports the missing calls once d has
+// elapsed.
+func (ctrl *Controller) FinishWithTimeout(d time.Duration) {
+	// If we're currently panicking, probably because this is a deferred call.
+	// This must be recovered in the deferred function.
+	err := recover()
+	if err == nil {
+		ctrl.waitSatisfied(d)
+	}
+	ctrl.finish(false, err)
+}
+
+// waitSatisfied polls until all expected calls are satisfied or d elapses.
+func (ctrl *Controller) waitSatisfied(d time.Duration) {
+	const pollInterval = 5 * time.Millisecond
+
+	deadline := time.Now().Add(d)
+	for !ctrl.Satisfied() {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return
+		}
+		if remaining > pollInterval {
+			remaining = pollInterval
+		}
+		time.Sleep(remaining)
+	}
+}
+
 // Satisfied returns whether all expected calls bound to this Controller have been satisfied.
 // Calling Finish is then guaranteed to not fail due to missing calls.
+// Satisfied does not report failures or modify any state, so it can be used to
+// poll for progress in asynchronous tests.
 func (ctrl *Controller) Satisfied() bool {
 	ctrl.mu.Lock()
 	defer ctrl.mu.Unlock()
 	return ctrl.expectedCalls.Satisfied()
 }
 
+// Reset removes all expectations recorded on this Controller and allows
+// Finish to be called again, so that a Controller can be reused, e.g. across
+// the cases of a table-driven test. The TestReporter is left unchanged.
+// Calling the mocks from other goroutines while Reset is in progress results
+// in undefined behavior.
+func (ctrl *Controller) Reset() {
+	ctrl.mu.Lock()
+	defer ctrl.mu.Unlock()
+
+	ctrl.expectedCalls.Reset()
+	ctrl.lastRecorded = nil
+	ctrl.unexpected = nil
+	ctrl.finished = false
+}
+
+// UnsatisfiedCalls returns a description of every expected call that has not
+// yet been made its minimum number of times, including the receiver type,
+// method, argument matchers, origin and the number of calls still missing.
+// It is safe to call before Finish, e.g. to log outstanding expectations.
+func (ctrl *Controller) UnsatisfiedCalls() []string {
+	ctrl.mu.Lock()
+	defer ctrl.mu.Unlock()
+
+	failures := ctrl.expectedCalls.Failures()
+	descs := make([]string, 0, len(failures))
+	for _, call := range failures {
+		if call.countOK != nil {
+			descs = append(descs, fmt.Sprintf("%v (%d call(s) made, rejected by TimesMatching)", call, call.numCalls))
+			continue
+		}
+		descs = append(descs, fmt.Sprintf("%v (%d more call(s) expected)", call, call.minCalls-call.numCalls))
+	}
+	sort.Strings(descs)
+	return descs
+}
+
 func (ctrl *Controller) finish(cleanup bool, panicErr any) {
 	ctrl.T.Helper()
 
@@ -279,9 +848,6 @@ func (ctrl *Controller) finish(cleanup bool, panicErr any) {
 	defer ctrl.mu.Unlock()
 
 	if ctrl.finished {
-		if _, ok := isCleanuper(ctrl.T); !ok {
-			ctrl.T.Fatalf("Controller.Finish was called more than once. It has to be called exactly once.")
-		}
 		return
 	}
 	ctrl.finished = true
@@ -291,18 +857,43 @@ func (ctrl *Controller) finish(cleanup bool, panicErr any) {
 		panic(panicErr)
 	}
 
+	for _, child := range ctrl.children {
+		child.finish(true, nil)
+	}
+
+	// Report the unexpected calls recorded with WithDeferredFailures.
+	for _, msg := range ctrl.unexpected {
+		ctrl.T.Errorf("%s", msg)
+	}
+
 	// Check that all remaining expected calls are satisfied.
 	failures := ctrl.expectedCalls.Failures()
 	for _, call := range failures {
+		if call.countOK != nil {
+			ctrl.T.Errorf("wrong number of calls (%d) to %v", call.numCalls, call)
+			continue
+		}
+		if call.group != nil {
+			ctrl.T.Errorf("missing call(s) to %v, whose AllOrNone group was partially made", call)
+			continue
+		}
 		ctrl.T.Errorf("missing call(s) to %v", call)
 	}
-	if len(failures) != 0 {
-		if !cleanup {
-			ctrl.T.Fatalf("aborting test due to missing call(s)")
-			return
-		}
-		ctrl.T.Errorf("aborting test due to missing call(s)")
+
+	reason := ""
+	switch {
+	case len(failures) != 0:
+		reason = "missing call(s)"
+	case len(ctrl.unexpected) != 0:
+		reason = "unexpected call(s)"
+	default:
+		return
+	}
+	if !cleanup {
+		ctrl.T.Fatalf("aborting test due to %s", reason)
+		return
 	}
+	ctrl.T.Errorf("aborting test due to %s", reason)
 }
 
 // callerInfo returns the file:line of the call site. skip is the number
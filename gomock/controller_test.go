@@ -15,12 +15,18 @@
 package gomock_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"go.uber.org/mock/gomock"
+	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 )
 
@@ -151,6 +157,22 @@ func (s *Subject) BarMethod(arg string) int {
 
 func (s *Subject) VariadicMethod(arg int, vararg ...string) {}
 
+func (s *Subject) VariadicPtrMethod(first int, rest ...*int) {}
+
+func (s *Subject) ErrorMethod(err error) {}
+
+func (s *Subject) FloatsMethod(fs []float64) {}
+
+func (s *Subject) ContextMethod(ctx context.Context, id string) {}
+
+func (s *Subject) FetchMethod(key string) (int, error) {
+	return 0, nil
+}
+
+func (s *Subject) CountMethod() (count int, err error) {
+	return 0, nil
+}
+
 // A type purely for ActOnTestStructMethod
 type TestStruct struct {
 	Number        int
@@ -158,6 +180,19 @@ type TestStruct struct {
 	secretMessage string
 }
 
+type Measurement struct {
+	Value  float64
+	sensor string
+}
+
+func (s *Subject) MeasureMethod(m Measurement) {}
+
+func (s *Subject) LabelsMethod(labels map[string]string) {}
+
+func (s *Subject) ErrOutMethod(result *int, errOut *error) {}
+
+func (s *Subject) SumMethod(a, b int, sum *int) {}
+
 func (s *Subject) ActOnTestStructMethod(arg TestStruct, arg1 int) int {
 	return 0
 }
@@ -246,6 +281,52 @@ func TestUnexpectedMethodCall(t *testing.T) {
 	})
 }
 
+func TestTryRecordCall(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	call, err := ctrl.TryRecordCall(subject, "FooMethod", "argument")
+	if err != nil {
+		t.Fatalf("TryRecordCall() = %v", err)
+	}
+	call.Return(1)
+	if got := ctrl.Call(subject, "FooMethod", "argument"); got[0] != 1 {
+		t.Errorf("Call() = %v, want 1", got[0])
+	}
+	if _, err := ctrl.TryRecordCall(subject, "VariadicMethod", 0, "a", "b"); err != nil {
+		t.Errorf("TryRecordCall() with varargs = %v", err)
+	}
+	ctrl.Call(subject, "VariadicMethod", 0, "a", "b")
+	reporter.assertPass("calls recorded with TryRecordCall should be expected")
+}
+
+func TestTryRecordCallErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		receiver any
+		method   string
+		args     []any
+		wantErr  string
+	}{
+		{"unknown method", new(Subject), "BazMethod", []any{"a"}, "gomock: failed finding method BazMethod on *gomock_test.Subject"},
+		{"nil receiver", nil, "FooMethod", []any{"a"}, "gomock: failed finding method FooMethod on <nil>"},
+		{"too few arguments", new(Subject), "FooMethod", nil, "gomock: *gomock_test.Subject.FooMethod takes 1 argument(s), got 0"},
+		{"too many arguments", new(Subject), "FooMethod", []any{"a", "b"}, "gomock: *gomock_test.Subject.FooMethod takes 1 argument(s), got 2"},
+		{"too few variadic arguments", new(Subject), "VariadicMethod", nil, "gomock: *gomock_test.Subject.VariadicMethod takes at least 1 argument(s), got 0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reporter, ctrl := createFixtures(t)
+
+			call, err := ctrl.TryRecordCall(tt.receiver, tt.method, tt.args...)
+			if call != nil || err == nil || err.Error() != tt.wantErr {
+				t.Errorf("TryRecordCall() = %v, %v, want nil, %q", call, err, tt.wantErr)
+			}
+			reporter.assertPass("TryRecordCall should not fail the test")
+		})
+	}
+}
+
 func TestRepeatedCall(t *testing.T) {
 	reporter, ctrl := createFixtures(t)
 	subject := new(Subject)
@@ -395,6 +476,433 @@ func TestUnexpectedArgValue_GotFormatter(t *testing.T) {
 	})
 }
 
+func TestUnexpectedArgValue_EqWithFormat(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	defer reporter.recoverUnexpectedFatal()
+	subject := new(Subject)
+
+	ctrl.RecordCall(
+		subject,
+		"ActOnTestStructMethod",
+		gomock.EqWithFormat(TestStruct{Number: 123, Message: "hello"}, func(x any) string {
+			return fmt.Sprintf("TestStruct#%d", x.(TestStruct).Number)
+		}),
+		15,
+	)
+
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{Number: 456, Message: "hello"}, 15)
+	}, "Unexpected call to", "doesn't match the argument at index 0",
+		"Got: TestStruct#456\nWant: is equal to TestStruct#123")
+
+	reporter.assertFatal(func() {
+		// The expected call wasn't made.
+		ctrl.Finish()
+	})
+}
+
+func TestUnexpectedArgValue_WithGotFormat(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	defer reporter.recoverUnexpectedFatal()
+	subject := new(Subject)
+
+	ctrl.RecordCall(
+		subject,
+		"ActOnTestStructMethod",
+		TestStruct{Number: 123, Message: "hello"},
+		gomock.WithGotFormat(gomock.Eq(15), func(x any) string {
+			return fmt.Sprintf("%02d", x)
+		}),
+	)
+
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{Number: 123, Message: "hello"}, 3)
+	}, "Unexpected call to", "doesn't match the argument at index 1",
+		"Got: 03\nWant: is equal to 15")
+
+	ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{Number: 123, Message: "hello"}, 15)
+	ctrl.Finish()
+}
+
+// numberMatcher matches a TestStruct by Number and summarizes mismatches in
+// one line.
+type numberMatcher struct{ number int }
+
+func (m numberMatcher) Matches(x any) bool {
+	ts, ok := x.(TestStruct)
+	return ok && ts.Number == m.number
+}
+
+func (m numberMatcher) String() string {
+	return fmt.Sprintf("has Number %d", m.number)
+}
+
+func (m numberMatcher) Diff(x any, _ ...cmp.Option) string {
+	return fmt.Sprintf("Number is %d, want %d", x.(TestStruct).Number, m.number)
+}
+
+func TestUnexpectedArgValue_CustomDiffer(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "ActOnTestStructMethod", numberMatcher{123}, 15)
+
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{Number: 456, Message: "hello"}, 15)
+	}, "Unexpected call to", "doesn't match the argument at index 0",
+		"Diff (-want +got): Number is 456, want 123")
+
+	ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{Number: 123}, 15)
+}
+
+func TestWithMaxDiffLen(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithMaxDiffLen(9))
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "ActOnTestStructMethod", numberMatcher{123}, 15)
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{Number: 456}, 15)
+	}, "Diff (-want +got): Number is… (truncated)")
+
+	reporter = NewErrorReporter(t)
+	ctrl = gomock.NewController(reporter, gomock.WithMaxDiffLen(100))
+	ctrl.RecordCall(subject, "ActOnTestStructMethod", numberMatcher{123}, 15)
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{Number: 456}, 15)
+	}, "Diff (-want +got): Number is 456, want 123")
+}
+
+func TestWhen(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	flagEnabled := false
+	ctrl.RecordCall(subject, "FooMethod", gomock.When(func() bool { return flagEnabled }, "a")).Return(1)
+	ctrl.RecordCall(subject, "FooMethod", "a").Return(2)
+
+	if got := ctrl.Call(subject, "FooMethod", "a"); got[0] != 2 {
+		t.Errorf("with the flag disabled, Call() = %v, want 2", got[0])
+	}
+	flagEnabled = true
+	if got := ctrl.Call(subject, "FooMethod", "a"); got[0] != 1 {
+		t.Errorf("with the flag enabled, Call() = %v, want 1", got[0])
+	}
+	reporter.assertPass("When should enable the expectation once the condition holds")
+}
+
+func TestAnyLog(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	m := gomock.AnyLog(reporter)
+	if got, want := m.String(), "is anything"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	ctrl.RecordCall(subject, "FooMethod", m).Times(2)
+	ctrl.Call(subject, "FooMethod", "first")
+	ctrl.Call(subject, "FooMethod", "second")
+	reporter.assertPass("AnyLog should match any argument")
+
+	want := []string{
+		"gomock: AnyLog received first (string)",
+		"gomock: AnyLog received second (string)",
+	}
+	if !reflect.DeepEqual(reporter.log, want) {
+		t.Errorf("logged %q, want %q", reporter.log, want)
+	}
+}
+
+func TestWithArgTransformer(t *testing.T) {
+	trim := func(arg any) any {
+		if s, ok := arg.(string); ok {
+			return strings.TrimSpace(s)
+		}
+		return arg
+	}
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithArgTransformer(trim))
+	subject := new(Subject)
+
+	var got []string
+	ctrl.RecordCall(subject, "FooMethod", "x").
+		Do(func(arg string) { got = append(got, arg) }).
+		Times(2)
+	ctrl.Call(subject, "FooMethod", " x ")
+	ctrl.RecordCall(subject, "FooMethod", gomock.Eq("y  "))
+	ctrl.RecordCall(subject, "FooMethod", "\tz").Return(1)
+	if rets := ctrl.Call(subject, "FooMethod", "z"); rets[0] != 1 {
+		t.Errorf("Call() = %v, want 1", rets[0])
+	}
+	ctrl.Call(subject, "FooMethod", "x\n")
+	reporter.assertPass("received and recorded strings should be trimmed")
+
+	if want := []string{" x ", "x\n"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Do got %q, want the original arguments %q", got, want)
+	}
+
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", " y  ")
+	}, "Unexpected call to *gomock_test.Subject.FooMethod([y])",
+		"doesn't match the argument at index 0", `"y  ",`, `"y",`)
+}
+
+func TestUnexpectedArgValue_MapDiff(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "LabelsMethod", map[string]string{"env": "prod", "team": "a", "zone": "1"})
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "LabelsMethod", map[string]string{"env": "prod", "team": "b", "zone": "1"})
+	}, "doesn't match the argument at index 0.\nDiff (-want +got): \nteam: got: b, want: a")
+	if msg := reporter.log[len(reporter.log)-1]; strings.Contains(msg, "env: ") || strings.Contains(msg, "zone: ") {
+		t.Errorf("failure message %q mentions unchanged keys", msg)
+	}
+}
+
+func TestWithMatchShuffle(t *testing.T) {
+	// Equally matching expectations are consumed in an order that depends on
+	// the seed.
+	orders := make(map[string]bool)
+	for seed := int64(0); seed < 20; seed++ {
+		reporter := NewErrorReporter(t)
+		ctrl := gomock.NewController(reporter, gomock.WithMatchShuffle(seed))
+		subject := new(Subject)
+		ctrl.RecordCall(subject, "FooMethod", "a").Return(1)
+		ctrl.RecordCall(subject, "FooMethod", "a").Return(2)
+
+		first := ctrl.Call(subject, "FooMethod", "a")[0]
+		second := ctrl.Call(subject, "FooMethod", "a")[0]
+		orders[fmt.Sprint(first, second)] = true
+		ctrl.Finish()
+		reporter.assertPass("both expectations should be consumed")
+	}
+	if !orders["1 2"] || !orders["2 1"] {
+		t.Errorf("got orders %v, want both 1 2 and 2 1", orders)
+	}
+
+	// Declared orderings still hold.
+	for seed := int64(0); seed < 20; seed++ {
+		reporter := NewErrorReporter(t)
+		ctrl := gomock.NewController(reporter, gomock.WithMatchShuffle(seed))
+		subject := new(Subject)
+		gomock.InOrder(
+			ctrl.RecordCall(subject, "FooMethod", "a").Return(1),
+			ctrl.RecordCall(subject, "FooMethod", "a").Return(2),
+		)
+
+		first := ctrl.Call(subject, "FooMethod", "a")[0]
+		second := ctrl.Call(subject, "FooMethod", "a")[0]
+		if first != 1 || second != 2 {
+			t.Errorf("with seed %d, got %v then %v, want 1 then 2", seed, first, second)
+		}
+		ctrl.Finish()
+		reporter.assertPass("InOrder calls should be consumed in order")
+	}
+}
+
+func TestWithReflectEqual(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithReflectEqual())
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "MeasureMethod", Measurement{Value: 1, sensor: "a"}).Times(2)
+	ctrl.Call(subject, "MeasureMethod", Measurement{Value: 1, sensor: "a"})
+	reporter.assertPass("equal values with unexported fields should match")
+
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "MeasureMethod", Measurement{Value: 2, sensor: "b"})
+	}, "doesn't match the argument at index 0.",
+		"Diff (-want +got):\n-{Value:1 sensor:a}\n+{Value:2 sensor:b}")
+}
+
+func TestInAnyOrderWithCmpOpts(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithCmpOpts(cmpopts.EquateApprox(0, 0.01)))
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FloatsMethod", gomock.InAnyOrder([]float64{1, 1, 2}))
+	ctrl.Call(subject, "FloatsMethod", []float64{2.001, 1.001, 0.999})
+	reporter.assertPass("elements should be compared with the Controller's cmp options")
+
+	ctrl.RecordCall(subject, "FloatsMethod", gomock.InAnyOrder([]float64{1, 1, 2}))
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FloatsMethod", []float64{1, 2, 2})
+	}, "doesn't match the argument at index 0")
+}
+
+func TestDoWithContext(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var got context.Context
+	ctrl.RecordCall(subject, "ContextMethod", gomock.NotCancelledContext(), "id").
+		DoWithContext(func(ctx context.Context) { got = ctx }).
+		Times(2)
+
+	ctrl.Call(subject, "ContextMethod", ctx, "id")
+	if got != ctx {
+		t.Errorf("DoWithContext got %v, want the context passed to the call", got)
+	}
+	reporter.assertPass("a live context should match")
+
+	cancel()
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "ContextMethod", ctx, "id")
+	}, "Want: is a live (non-cancelled) context")
+}
+
+func TestDoWithContext_NoContextArgument(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	reporter.assertFatal(func() {
+		ctrl.RecordCall(subject, "FooMethod", "argument").DoWithContext(func(context.Context) {})
+	}, "DoWithContext called for *gomock_test.Subject.FooMethod, whose first argument is not a context.Context")
+}
+
+func TestDoContext(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "value")
+	var gotCtx context.Context
+	var gotArgs []any
+	ctrl.RecordCall(subject, "ContextMethod", gomock.Any(), "id").DoContext(func(ctx context.Context, args ...any) {
+		gotCtx, gotArgs = ctx, args
+	})
+
+	ctrl.Call(subject, "ContextMethod", ctx, "id")
+	if gotCtx != ctx {
+		t.Errorf("DoContext got %v, want the context passed to the call", gotCtx)
+	}
+	assertEqual(t, []any{"id"}, gotArgs)
+	reporter.assertPass("DoContext")
+
+	reporter.assertFatal(func() {
+		ctrl.RecordCall(subject, "FooMethod", "argument").DoContext(func(context.Context, ...any) {})
+	}, "DoContext called for *gomock_test.Subject.FooMethod, whose first argument is not a context.Context")
+}
+
+func TestSetDefaultControllerOptions(t *testing.T) {
+	gomock.SetDefaultControllerOptions(gomock.WithCmpOpts(cmpopts.EquateApprox(0, 0.01)))
+	defer gomock.SetDefaultControllerOptions()
+
+	t.Run("defaults apply", func(t *testing.T) {
+		reporter := NewErrorReporter(t)
+		ctrl := gomock.NewController(reporter)
+		subject := new(Subject)
+
+		ctrl.RecordCall(subject, "FloatsMethod", gomock.InAnyOrder([]float64{1, 2}))
+		ctrl.Call(subject, "FloatsMethod", []float64{2.001, 0.999})
+		reporter.assertPass("the default cmp options should be used")
+	})
+
+	t.Run("explicit options override defaults", func(t *testing.T) {
+		reporter := NewErrorReporter(t)
+		ctrl := gomock.NewController(reporter, gomock.WithCmpOpts())
+		subject := new(Subject)
+
+		ctrl.RecordCall(subject, "FloatsMethod", gomock.InAnyOrder([]float64{1, 2}))
+		reporter.assertFatal(func() {
+			ctrl.Call(subject, "FloatsMethod", []float64{2.001, 0.999})
+		}, "doesn't match the argument at index 0")
+	})
+
+	t.Run("defaults can be removed", func(t *testing.T) {
+		gomock.SetDefaultControllerOptions()
+		reporter := NewErrorReporter(t)
+		ctrl := gomock.NewController(reporter)
+		subject := new(Subject)
+
+		ctrl.RecordCall(subject, "FloatsMethod", gomock.InAnyOrder([]float64{1, 2}))
+		reporter.assertFatal(func() {
+			ctrl.Call(subject, "FloatsMethod", []float64{2.001, 0.999})
+		}, "doesn't match the argument at index 0")
+	})
+}
+
+func TestWithMatchSpecificity(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithMatchSpecificity())
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", gomock.Any()).Return(0).AnyTimes()
+	ctrl.RecordCall(subject, "FooMethod", "specific").Return(1)
+
+	assertEqual(t, []any{1}, ctrl.Call(subject, "FooMethod", "specific"))
+	// The specific expectation is exhausted, so the wildcard one is used.
+	assertEqual(t, []any{0}, ctrl.Call(subject, "FooMethod", "specific"))
+	assertEqual(t, []any{0}, ctrl.Call(subject, "FooMethod", "other"))
+	reporter.assertPass("the most specific matching call should be used first")
+}
+
+func TestWithoutMatchSpecificity(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", gomock.Any()).Return(0).Times(1)
+	ctrl.RecordCall(subject, "FooMethod", "specific").Return(1)
+
+	// The first recorded matching call is used.
+	assertEqual(t, []any{0}, ctrl.Call(subject, "FooMethod", "specific"))
+	assertEqual(t, []any{1}, ctrl.Call(subject, "FooMethod", "specific"))
+	reporter.assertPass("calls should be matched in recording order")
+}
+
+func TestCapture(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	var got TestStruct
+	var n int
+	ctrl.RecordCall(subject, "ActOnTestStructMethod", gomock.Capture(&got), gomock.Capture(&n)).Return(1)
+	ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{Number: 7, Message: "hello"}, 3)
+
+	assertEqual(t, TestStruct{Number: 7, Message: "hello"}, got)
+	assertEqual(t, 3, n)
+	reporter.assertPass("Capture should match any value of its type")
+}
+
+func TestEqWithOpts(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithCmpOpts(cmpopts.IgnoreUnexported(Measurement{})))
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "MeasureMethod",
+		gomock.EqWithOpts(Measurement{Value: 1.0, sensor: "a"}, cmpopts.EquateApprox(0, 0.01)))
+	ctrl.Call(subject, "MeasureMethod", Measurement{Value: 1.005, sensor: "b"})
+	reporter.assertPass("EqWithOpts combines controller and call-level options")
+
+	ctrl.RecordCall(subject, "MeasureMethod",
+		gomock.EqWithOpts(Measurement{Value: 1.0}, cmpopts.EquateApprox(0, 0.01)))
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "MeasureMethod", Measurement{Value: 2.0})
+	}, "Unexpected call to", "doesn't match the argument at index 0",
+		"Diff (-want +got):", "-", "Value: 1,", "+", "Value: 2,")
+}
+
+func TestEqWithOptsUnexportedFields(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "MeasureMethod",
+		gomock.EqWithOpts(Measurement{Value: 1.0, sensor: "a"}, cmpopts.EquateApprox(0, 0.01)))
+	ctrl.Call(subject, "MeasureMethod", Measurement{Value: 1.005, sensor: "a"})
+	reporter.assertPass("EqWithOpts compares unexported fields without controller options")
+
+	ctrl.RecordCall(subject, "MeasureMethod",
+		gomock.EqWithOpts(Measurement{Value: 1.0, sensor: "a"}, cmpopts.EquateApprox(0, 0.01)))
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "MeasureMethod", Measurement{Value: 1.0, sensor: "b"})
+	}, "Unexpected call to", "doesn't match the argument at index 0", "sensor:")
+}
+
 func TestAnyTimes(t *testing.T) {
 	reporter, ctrl := createFixtures(t)
 	subject := new(Subject)
@@ -505,26 +1013,115 @@ func TestMinMaxTimes(t *testing.T) {
 	ctrl.Finish()
 }
 
-func TestDo(t *testing.T) {
-	_, ctrl := createFixtures(t)
+func TestBetween(t *testing.T) {
+	// It fails if there are less calls than specified
+	reporter, ctrl := createFixtures(t)
 	subject := new(Subject)
+	ctrl.RecordCall(subject, "FooMethod", "argument").Between(2, 2)
+	ctrl.Call(subject, "FooMethod", "argument")
+	reporter.assertFatal(func() {
+		ctrl.Finish()
+	})
 
-	doCalled := false
-	var argument string
-	wantArg := "argument"
-	ctrl.RecordCall(subject, "FooMethod", wantArg).Do(
-		func(arg string) {
-			doCalled = true
-			argument = arg
-		})
-	if doCalled {
-		t.Error("Do() callback called too early.")
-	}
+	// It fails if there are more calls than specified
+	reporter, ctrl = createFixtures(t)
+	subject = new(Subject)
+	ctrl.RecordCall(subject, "FooMethod", "argument").Between(2, 2)
+	ctrl.Call(subject, "FooMethod", "argument")
+	ctrl.Call(subject, "FooMethod", "argument")
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "argument")
+	})
 
-	ctrl.Call(subject, "FooMethod", wantArg)
+	// It succeeds if there is just the right number of calls
+	_, ctrl = createFixtures(t)
+	subject = new(Subject)
+	ctrl.RecordCall(subject, "FooMethod", "argument").Between(2, 2)
+	ctrl.Call(subject, "FooMethod", "argument")
+	ctrl.Call(subject, "FooMethod", "argument")
+	ctrl.Finish()
 
-	if !doCalled {
-		t.Error("Do() callback not called.")
+	// Like MinTimes(1).MaxTimes(2), Between(1, 2) limits the calls to 2.
+	reporter, ctrl = createFixtures(t)
+	subject = new(Subject)
+	ctrl.RecordCall(subject, "FooMethod", "argument").Between(1, 2)
+	ctrl.Call(subject, "FooMethod", "argument")
+	ctrl.Call(subject, "FooMethod", "argument")
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "argument")
+	})
+
+	// Like MinTimes(1).MaxTimes(2), Between(1, 2) then requires no call.
+	reporter, ctrl = createFixtures(t)
+	subject = new(Subject)
+	ctrl.RecordCall(subject, "FooMethod", "argument").Between(1, 2)
+	ctrl.Finish()
+	reporter.assertPass("Between(1, 2) should behave like MinTimes(1).MaxTimes(2)")
+}
+
+func TestTimesMatching(t *testing.T) {
+	even := func(n int) bool { return n%2 == 0 }
+
+	// It succeeds for any count accepted by the predicate.
+	for _, n := range []int{0, 2, 4} {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+		ctrl.RecordCall(subject, "FooMethod", "argument").TimesMatching(even)
+		for i := 0; i < n; i++ {
+			ctrl.Call(subject, "FooMethod", "argument")
+		}
+		ctrl.Finish()
+		reporter.assertPass("an even number of calls should be accepted")
+	}
+
+	// It fails at Finish, reporting the number of calls, otherwise.
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+	ctrl.RecordCall(subject, "FooMethod", "argument").TimesMatching(even)
+	for i := 0; i < 3; i++ {
+		ctrl.Call(subject, "FooMethod", "argument")
+	}
+	reporter.assertPass("calls should not be limited before Finish")
+	reporter.assertFatal(func() {
+		ctrl.Finish()
+	}, "aborting test due to missing call(s)")
+	if got, want := reporter.log[0], "wrong number of calls (3) to *gomock_test.Subject.FooMethod(is equal to argument (string))"; !strings.HasPrefix(got, want) {
+		t.Errorf("Finish reported %q, want prefix %q", got, want)
+	}
+}
+
+func TestBetweenInvalidBounds(t *testing.T) {
+	_, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Between(3, 2) did not panic")
+		}
+	}()
+	ctrl.RecordCall(subject, "FooMethod", "argument").Between(3, 2)
+}
+
+func TestDo(t *testing.T) {
+	_, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	doCalled := false
+	var argument string
+	wantArg := "argument"
+	ctrl.RecordCall(subject, "FooMethod", wantArg).Do(
+		func(arg string) {
+			doCalled = true
+			argument = arg
+		})
+	if doCalled {
+		t.Error("Do() callback called too early.")
+	}
+
+	ctrl.Call(subject, "FooMethod", wantArg)
+
+	if !doCalled {
+		t.Error("Do() callback not called.")
 	}
 	if wantArg != argument {
 		t.Error("Do callback received wrong argument.")
@@ -566,6 +1163,397 @@ func TestDoAndReturn(t *testing.T) {
 	}
 }
 
+func TestDelay(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "argument").Return(5).Delay(50 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	rets := ctrl.Call(subject, "FooMethod", "argument")
+	if ctx.Err() != context.DeadlineExceeded {
+		t.Errorf("Call returned before the delay elapsed")
+	}
+	assertEqual(t, []any{5}, rets)
+	reporter.assertPass("After delayed call")
+}
+
+func TestDoWithAssignableParams(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	var got []any
+	ctrl.RecordCall(subject, "ActOnTestStructMethod", gomock.Any(), gomock.Any()).Do(func(arg, arg1 any) {
+		got = append(got, arg, arg1)
+	})
+	ctrl.RecordCall(subject, "VariadicMethod", gomock.Any(), gomock.Any()).Do(func(arg any, vararg ...any) {
+		got = append(got, arg)
+		got = append(got, vararg...)
+	})
+	ctrl.RecordCall(subject, "FooMethod", gomock.Any()).DoAndReturn(func(arg any) any {
+		return len(arg.(string))
+	})
+
+	ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{Number: 1}, 2)
+	ctrl.Call(subject, "VariadicMethod", 3, "a", "b")
+	rets := ctrl.Call(subject, "FooMethod", "hello")
+	assertEqual(t, []any{TestStruct{Number: 1}, 2, 3, "a", "b"}, got)
+	assertEqual(t, []any{5}, rets)
+	reporter.assertPass("Do and DoAndReturn with assignable parameter types")
+}
+
+func TestDoAndReturnArgs(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	var got []any
+	ctrl.RecordCall(subject, "FooMethod", "argument").DoAndReturnArgs(func(args []any) []any {
+		got = args
+		return []any{len(args[0].(string))}
+	})
+
+	rets := ctrl.Call(subject, "FooMethod", "argument")
+	assertEqual(t, []any{"argument"}, got)
+	assertEqual(t, []any{8}, rets)
+	reporter.assertPass("DoAndReturnArgs")
+}
+
+func TestDoAndReturnArgsVariadic(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	var got []any
+	ctrl.RecordCall(subject, "VariadicMethod", 0, gomock.Any()).DoAndReturnArgs(func(args []any) []any {
+		got = args
+		return nil
+	})
+
+	ctrl.Call(subject, "VariadicMethod", 0, "1", "2")
+	assertEqual(t, []any{0, "1", "2"}, got)
+	reporter.assertPass("DoAndReturnArgs with variadic arguments")
+}
+
+func TestDoAndReturnArgsWrongReturns(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "count").DoAndReturnArgs(func([]any) []any {
+		return []any{1, 2}
+	})
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "count")
+	}, "wrong number of arguments to DoAndReturnArgs for *gomock_test.Subject.FooMethod: got 2, want 1")
+
+	ctrl.RecordCall(subject, "FooMethod", "type").DoAndReturnArgs(func([]any) []any {
+		return []any{"one"}
+	})
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "type")
+	}, "wrong type of argument 0 to DoAndReturnArgs for *gomock_test.Subject.FooMethod: string is not assignable to int")
+}
+
+func TestDoOrReturn(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	var done []string
+	ctrl.RecordCall(subject, "FooMethod", gomock.Any()).AnyTimes().DoOrReturn(func(args []any) ([]any, bool) {
+		if args[0] == "special" {
+			return []any{-1}, true
+		}
+		return nil, false
+	}).Do(func(arg string) {
+		done = append(done, arg)
+	}).Return(1)
+
+	if got := ctrl.Call(subject, "FooMethod", "special"); got[0] != -1 {
+		t.Errorf("handled call returned %v, want -1", got[0])
+	}
+	if got := ctrl.Call(subject, "FooMethod", "regular"); got[0] != 1 {
+		t.Errorf("unhandled call returned %v, want 1", got[0])
+	}
+	assertEqual(t, []string{"regular"}, done)
+	reporter.assertPass("DoOrReturn")
+}
+
+func TestDoOrReturnWrongReturns(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "count").Return(1).DoOrReturn(func([]any) ([]any, bool) {
+		return []any{1, 2}, true
+	})
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "count")
+	}, "wrong number of arguments to DoOrReturn for *gomock_test.Subject.FooMethod: got 2, want 1")
+}
+
+func TestReturnFromMap(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	errNotFound := errors.New("not found")
+	ctrl.RecordCall(subject, "FetchMethod", gomock.Any()).AnyTimes().ReturnFromMap(0, map[any][]any{
+		"a": {1, nil},
+		"b": {2, nil},
+		"c": {0, errNotFound},
+	})
+
+	tests := []struct {
+		key  string
+		want []any
+	}{
+		{"b", []any{2, nil}},
+		{"a", []any{1, nil}},
+		{"c", []any{0, errNotFound}},
+		{"a", []any{1, nil}},
+	}
+	for _, tt := range tests {
+		if got := ctrl.Call(subject, "FetchMethod", tt.key); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("FetchMethod(%q) = %v, want %v", tt.key, got, tt.want)
+		}
+	}
+	reporter.assertPass("ReturnFromMap should return the tuple for the key")
+
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FetchMethod", "d")
+	}, "ReturnFromMap(0, ...) has no entry for argument d (string)")
+}
+
+func TestReturnFromMapInvalidTable(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	reporter.assertFatal(func() {
+		ctrl.RecordCall(subject, "FetchMethod", gomock.Any()).ReturnFromMap(0, map[any][]any{
+			"a": {"one", nil},
+		})
+	}, "wrong type of argument 0 to ReturnFromMap for *gomock_test.Subject.FetchMethod: string is not assignable to int")
+
+	reporter.assertFatal(func() {
+		ctrl.RecordCall(subject, "FetchMethod", gomock.Any()).ReturnFromMap(1, nil)
+	}, "ReturnFromMap(1, ...) called for a method with 1 args")
+}
+
+func TestDoAndReturnNamed(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	errBoom := errors.New("boom")
+	ctrl.RecordCall(subject, "CountMethod").DoAndReturnNamed(func() (r struct {
+		Count int
+		Err   error
+	}) {
+		r.Count, r.Err = 3, errBoom
+		return r
+	})
+	rets := ctrl.Call(subject, "CountMethod")
+	if got, want := rets, []any{3, errBoom}; !reflect.DeepEqual(got, want) {
+		t.Errorf("results = %v, want %v", got, want)
+	}
+
+	// A nil error field is returned as a nil error.
+	ctrl.RecordCall(subject, "CountMethod").DoAndReturnNamed(func() struct {
+		Count int
+		Err   error
+	} {
+		return struct {
+			Count int
+			Err   error
+		}{Count: 1}
+	})
+	rets = ctrl.Call(subject, "CountMethod")
+	if err, ok := rets[1].(error); rets[0] != 1 || ok || err != nil {
+		t.Errorf("results = %v, want [1 <nil>]", rets)
+	}
+	reporter.assertPass("DoAndReturnNamed should set the results from the struct fields")
+}
+
+func TestDoAndReturnNamedInvalidStruct(t *testing.T) {
+	tests := []struct {
+		name    string
+		f       any
+		wantErr string
+	}{
+		{"not a struct", func() (int, error) { return 0, nil }, "takes a func returning a struct"},
+		{"too many args", func(string) struct {
+			Count int
+			Err   error
+		} {
+			return struct {
+				Count int
+				Err   error
+			}{}
+		}, "wrong number of arguments"},
+		{"too few fields", func() struct{ Count int } { return struct{ Count int }{} }, "wrong number of fields"},
+		{"unexported field", func() struct {
+			Count int
+			err   error
+		} {
+			return struct {
+				Count int
+				err   error
+			}{}
+		}, "field err of DoAndReturnNamed result"},
+		{"wrong type", func() struct {
+			Count string
+			Err   error
+		} {
+			return struct {
+				Count string
+				Err   error
+			}{}
+		}, "field Count of DoAndReturnNamed result for *gomock_test.Subject.CountMethod has type string, which is not assignable to result 0 of type int"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reporter, ctrl := createFixtures(t)
+			subject := new(Subject)
+			call := ctrl.RecordCall(subject, "CountMethod")
+			reporter.assertFatal(func() {
+				call.DoAndReturnNamed(tt.f)
+			}, tt.wantErr)
+		})
+	}
+}
+
+func TestDoAndReturnN(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	var indexes []int
+	ctrl.RecordCall(subject, "FooMethod", gomock.Any()).Times(3).DoAndReturnN(func(callIndex int, args []any) []any {
+		indexes = append(indexes, callIndex)
+		return []any{callIndex * 10}
+	})
+
+	assertEqual(t, []any{0}, ctrl.Call(subject, "FooMethod", "a"))
+	assertEqual(t, []any{10}, ctrl.Call(subject, "FooMethod", "b"))
+	assertEqual(t, []any{20}, ctrl.Call(subject, "FooMethod", "c"))
+	assertEqual(t, []int{0, 1, 2}, indexes)
+	reporter.assertPass("DoAndReturnN")
+}
+
+func TestDoAndReturnNWrongReturns(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "count").DoAndReturnN(func(int, []any) []any {
+		return nil
+	})
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "count")
+	}, "wrong number of arguments to DoAndReturnN for *gomock_test.Subject.FooMethod: got 0, want 1")
+}
+
+func TestSetArgFunc(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "SumMethod", gomock.Any(), gomock.Any(), gomock.Any()).
+		SetArgFunc(2, func(args []any) any {
+			return args[0].(int) + args[1].(int)
+		}).
+		Times(2)
+
+	var sum int
+	ctrl.Call(subject, "SumMethod", 1, 2, &sum)
+	if sum != 3 {
+		t.Errorf("sum = %d, want 3", sum)
+	}
+	ctrl.Call(subject, "SumMethod", 20, 22, &sum)
+	if sum != 42 {
+		t.Errorf("sum = %d, want 42", sum)
+	}
+	reporter.assertPass("SetArgFunc should set the out-parameter from the inputs")
+}
+
+func TestSetArgFuncInvalidValue(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "SumMethod", gomock.Any(), gomock.Any(), gomock.Any()).
+		SetArgFunc(2, func(args []any) any { return "three" })
+
+	var sum int
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "SumMethod", 1, 2, &sum)
+	}, "SetArgFunc(2, ...) argument is a string, not assignable to int")
+
+	reporter.assertFatal(func() {
+		ctrl.RecordCall(subject, "SumMethod", 1, 2, &sum).SetArgFunc(3, func([]any) any { return 0 })
+	}, "SetArgFunc(3, ...) called for a method with 3 args")
+}
+
+func TestSetArgError(t *testing.T) {
+	_, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	wantErr := &codeError{code: 7}
+	ctrl.RecordCall(subject, "ErrOutMethod", gomock.Any(), gomock.Any()).SetArg(0, 3).SetArgError(1, wantErr)
+	ctrl.RecordCall(subject, "ErrOutMethod", gomock.Any(), gomock.Any()).SetArgError(1, nil)
+	ctrl.RecordCall(subject, "SetArgMethodInterface", nil, nil, gomock.Any()).SetArgError(2, io.EOF)
+
+	var result int
+	var err error
+	ctrl.Call(subject, "ErrOutMethod", &result, &err)
+	if result != 3 || err != wantErr {
+		t.Errorf("got result %d and error %v, want 3 and %v", result, err, wantErr)
+	}
+	ctrl.Call(subject, "ErrOutMethod", &result, &err)
+	if err != nil {
+		t.Errorf("got error %v, want it to be cleared", err)
+	}
+	ctrl.Call(subject, "SetArgMethodInterface", nil, nil, &err)
+	if err != io.EOF {
+		t.Errorf("got error %v through an interface argument, want %v", err, io.EOF)
+	}
+}
+
+func TestSetArgErrorInvalidArgument(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	reporter.assertFatal(func() {
+		ctrl.RecordCall(subject, "ErrOutMethod", gomock.Any(), gomock.Any()).SetArgError(0, io.EOF)
+	}, "SetArgError(0, ...) referring to argument of type *int, which is not *error or an interface")
+
+	ctrl.RecordCall(subject, "SetArgMethodInterface", nil, nil, gomock.Any()).SetArgError(2, io.EOF)
+	reporter.assertFatal(func() {
+		var result int
+		ctrl.Call(subject, "SetArgMethodInterface", nil, nil, &result)
+	}, "SetArgError(2, ...) called with a *int argument, which is not a non-nil *error")
+}
+
+func TestReturnNil(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FetchMethod", "a").Return(0, nil)
+	rets := ctrl.Call(subject, "FetchMethod", "a")
+	if err, ok := rets[1].(error); ok || err != nil {
+		t.Errorf("got error result %#v, want a nil error", rets[1])
+	}
+
+	reporter.assertFatal(func() {
+		ctrl.RecordCall(subject, "FetchMethod", "b").Return(nil, nil)
+	}, "argument 0 to Return for *gomock_test.Subject.FetchMethod is nil, but int is not nillable")
+}
+
+func TestReturnNilError(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FetchMethod", "a").ReturnNilError()
+	assertEqual(t, []any{0, nil}, ctrl.Call(subject, "FetchMethod", "a"))
+
+	reporter.assertFatal(func() {
+		ctrl.RecordCall(subject, "FooMethod", "a").ReturnNilError()
+	}, "ReturnNilError called for *gomock_test.Subject.FooMethod, whose last result is not an error")
+}
+
 func TestSetArgSlice(t *testing.T) {
 	_, ctrl := createFixtures(t)
 	subject := new(Subject)
@@ -629,6 +1617,73 @@ func TestSetArgPtr(t *testing.T) {
 	}
 }
 
+func TestSetArgInterfacePtr(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	var in int = 43
+	const set = 42
+	ctrl.RecordCall(subject, "SetArgMethodInterface", nil, gomock.Any(), nil).SetArg(1, set)
+	ctrl.Call(subject, "SetArgMethodInterface", nil, &in, nil)
+
+	if in != set {
+		t.Error("Expected SetArg() to modify value pointed to by argument as any")
+	}
+	reporter.assertPass("SetArg through an interface-typed argument")
+}
+
+func TestSetArgInterfacePtr_NotAssignableAtRecordTime(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	var in int
+	reporter.assertFatal(func() {
+		ctrl.RecordCall(subject, "SetArgMethodInterface", nil, &in, nil).SetArg(1, "42")
+	}, "SetArg(1, ...) argument is a string, not assignable to int")
+}
+
+func TestSetArgInterfacePtr_NotAssignableAtCallTime(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	var in int
+	ctrl.RecordCall(subject, "SetArgMethodInterface", nil, gomock.Any(), nil).SetArg(1, "42").Times(2)
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "SetArgMethodInterface", nil, &in, nil)
+	}, "SetArg(1, ...) argument is a string, not assignable to int")
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "SetArgMethodInterface", nil, in, nil)
+	}, "SetArg(1, ...) called with a int argument, which is not a pointer, slice or map")
+}
+
+func TestSetArgVariadic(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	a, b := 1, 2
+	ctrl.RecordCall(subject, "VariadicPtrMethod", 0, gomock.Any()).SetArg(2, 42)
+	ctrl.Call(subject, "VariadicPtrMethod", 0, &a, &b)
+
+	if a != 1 || b != 42 {
+		t.Errorf("SetArg(2, 42) on variadic args: got a=%d b=%d, want a=1 b=42", a, b)
+	}
+	reporter.assertPass("SetArg into variadic arguments")
+
+	ctrl.RecordCall(subject, "VariadicPtrMethod", 1, gomock.Any()).SetArg(3, 42)
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "VariadicPtrMethod", 1, &a)
+	}, "SetArg(3, ...) called for a call of *gomock_test.Subject.VariadicPtrMethod with 2 args")
+}
+
+func TestSetArgOutOfRange(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	reporter.assertFatal(func() {
+		ctrl.RecordCall(subject, "SetArgMethod", nil, nil, nil).SetArg(3, 1)
+	}, "SetArg(3, ...) called for a method with 3 args")
+}
+
 func TestReturn(t *testing.T) {
 	_, ctrl := createFixtures(t)
 	subject := new(Subject)
@@ -648,11 +1703,132 @@ func TestReturn(t *testing.T) {
 		ctrl.Call(subject, "FooMethod", "five"))
 }
 
-func TestUnorderedCalls(t *testing.T) {
+func TestReturnSequence(t *testing.T) {
 	reporter, ctrl := createFixtures(t)
-	defer reporter.recoverUnexpectedFatal()
-	subjectTwo := new(Subject)
-	subjectOne := new(Subject)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "argument").
+		ReturnSequence([]any{1}, []any{2}, []any{3}).
+		AnyTimes()
+
+	for _, want := range []int{1, 2, 3, 3} {
+		assertEqual(t, []any{want}, ctrl.Call(subject, "FooMethod", "argument"))
+	}
+	reporter.assertPass("After calls exceeding the sequence")
+}
+
+func TestReturnSequenceExceededWithExactTimes(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "argument").
+		ReturnSequence([]any{1}, []any{2}).
+		Times(3)
+
+	ctrl.Call(subject, "FooMethod", "argument")
+	ctrl.Call(subject, "FooMethod", "argument")
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "argument")
+	}, "ReturnSequence for *gomock_test.Subject.FooMethod has 2 return tuples, but the call was made 3 times")
+}
+
+func TestReturnSequenceWithBadType(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	reporter.assertFatal(func() {
+		ctrl.RecordCall(subject, "FooMethod", "argument").ReturnSequence([]any{1}, []any{"two"})
+	}, "wrong type of argument 0 to ReturnSequence tuple 1 for *gomock_test.Subject.FooMethod")
+}
+
+func TestUnexpectedArgValue_AssignableToTypeOf(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	writer := reflect.TypeOf((*io.Writer)(nil)).Elem()
+	ctrl.RecordCall(subject, "SetArgMethodInterface", gomock.AssignableToType(writer), nil, nil)
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "SetArgMethodInterface", strings.NewReader(""), nil, nil)
+	}, "doesn't match the argument at index 0.\nGot: *strings.Reader\nWant: is assignable to io.Writer")
+
+	ctrl.RecordCall(subject, "SetArgMethodInterface", gomock.AssignableToTypeOf(&Subject{}), nil, nil)
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "SetArgMethodInterface", Subject{}, nil, nil)
+	}, "Got: gomock_test.Subject\nWant: is assignable to *gomock_test.Subject")
+}
+
+func TestRecordCallWithMethodType(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	// Subject has no Lookup method; the signature is supplied explicitly.
+	lookupType := reflect.TypeOf(func(key string, n ...int) (string, bool) { return "", false })
+	ctrl.RecordCallWithMethodType(subject, "Lookup", lookupType, "key", 1, 2).Return("value", true)
+
+	assertEqual(t, []any{"value", true}, ctrl.Call(subject, "Lookup", "key", 1, 2))
+	reporter.assertPass("call recorded with an explicit method type")
+
+	reporter.assertFatal(func() {
+		ctrl.RecordCallWithMethodType(subject, "Lookup", lookupType, "key").Return("value")
+	}, "wrong number of arguments to Return for *gomock_test.Subject.Lookup: got 1, want 2")
+}
+
+func TestRecordCallWithMethodType_NotAFunc(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	reporter.assertFatal(func() {
+		ctrl.RecordCallWithMethodType(subject, "Lookup", reflect.TypeOf(""), "key")
+	}, "gomock: RecordCallWithMethodType for *gomock_test.Subject.Lookup called with non-func method type string")
+	reporter.assertFatal(func() {
+		ctrl.RecordCallWithMethodType(subject, "Lookup", nil, "key")
+	}, "called with non-func method type <nil>")
+}
+
+func TestReturnErrorThenSuccess(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	errUnavailable := errors.New("unavailable")
+	ctrl.RecordCall(subject, "FetchMethod", "key").
+		ReturnErrorThenSuccess(3, errUnavailable, 42, nil).
+		Times(4)
+
+	// A client retrying up to 3 times after the first failure.
+	for attempt := 1; attempt <= 4; attempt++ {
+		rets := ctrl.Call(subject, "FetchMethod", "key")
+		if attempt <= 3 {
+			assertEqual(t, []any{0, errUnavailable}, rets)
+			continue
+		}
+		assertEqual(t, []any{42, nil}, rets)
+	}
+	reporter.assertPass("errors followed by success")
+}
+
+func TestReturnErrorThenSuccessWithoutErrorResult(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	reporter.assertFatal(func() {
+		ctrl.RecordCall(subject, "FooMethod", "argument").ReturnErrorThenSuccess(1, errors.New("oops"), 1)
+	}, "ReturnErrorThenSuccess called for *gomock_test.Subject.FooMethod, whose last result is not an error")
+}
+
+func TestReturnErrorThenSuccessWithBadType(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	reporter.assertFatal(func() {
+		ctrl.RecordCall(subject, "FetchMethod", "key").ReturnErrorThenSuccess(1, errors.New("oops"), "42", nil)
+	}, "wrong type of argument 0 to ReturnErrorThenSuccess for *gomock_test.Subject.FetchMethod")
+}
+
+func TestUnorderedCalls(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	defer reporter.recoverUnexpectedFatal()
+	subjectTwo := new(Subject)
+	subjectOne := new(Subject)
 
 	ctrl.RecordCall(subjectOne, "FooMethod", "1")
 	ctrl.RecordCall(subjectOne, "BarMethod", "2")
@@ -731,6 +1907,69 @@ func TestTimes0(t *testing.T) {
 	})
 }
 
+func TestNever(t *testing.T) {
+	rep, ctrl := createFixtures(t)
+
+	s := new(Subject)
+	ctrl.RecordCall(s, "FooMethod", "arg").Never()
+	rep.assertFatal(func() {
+		ctrl.Call(s, "FooMethod", "arg")
+	})
+}
+
+func TestOnce(t *testing.T) {
+	// It fails if there are no calls
+	rep, ctrl := createFixtures(t)
+	s := new(Subject)
+	ctrl.RecordCall(s, "FooMethod", "arg").Once()
+	rep.assertFatal(func() {
+		ctrl.Finish()
+	})
+
+	// It fails if there are more calls
+	rep, ctrl = createFixtures(t)
+	ctrl.RecordCall(s, "FooMethod", "arg").Once()
+	ctrl.Call(s, "FooMethod", "arg")
+	rep.assertFatal(func() {
+		ctrl.Call(s, "FooMethod", "arg")
+	})
+
+	// It succeeds if there is exactly one call
+	rep, ctrl = createFixtures(t)
+	ctrl.RecordCall(s, "FooMethod", "arg").Once()
+	ctrl.Call(s, "FooMethod", "arg")
+	ctrl.Finish()
+	rep.assertPass("After one call")
+}
+
+func TestTwice(t *testing.T) {
+	// It fails if there are less calls
+	rep, ctrl := createFixtures(t)
+	s := new(Subject)
+	ctrl.RecordCall(s, "FooMethod", "arg").Twice()
+	ctrl.Call(s, "FooMethod", "arg")
+	rep.assertFatal(func() {
+		ctrl.Finish()
+	})
+
+	// It fails if there are more calls
+	rep, ctrl = createFixtures(t)
+	ctrl.RecordCall(s, "FooMethod", "arg").Twice()
+	ctrl.Call(s, "FooMethod", "arg")
+	ctrl.Call(s, "FooMethod", "arg")
+	rep.assertFatal(func() {
+		ctrl.Call(s, "FooMethod", "arg")
+	})
+
+	// It succeeds if there are exactly two calls
+	rep, ctrl = createFixtures(t)
+	ctrl.RecordCall(s, "FooMethod", "arg").Twice()
+	ctrl.Call(s, "FooMethod", "arg")
+	ctrl.Call(s, "FooMethod", "arg")
+	ctrl.Finish()
+	rep.assertPass("After two calls")
+}
+
 func TestVariadicMatching(t *testing.T) {
 	rep, ctrl := createFixtures(t)
 	defer rep.recoverUnexpectedFatal()
@@ -777,6 +2016,39 @@ func TestVariadicMatchingWithSlice(t *testing.T) {
 	}
 }
 
+func TestVariadicMatchingInAnyOrder(t *testing.T) {
+	testCases := [][]any{
+		{"a", "b"},
+		{"b", "a"},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprint(tc...), func(t *testing.T) {
+			rep, ctrl := createFixtures(t)
+			defer rep.recoverUnexpectedFatal()
+
+			s := new(Subject)
+			ctrl.RecordCall(s, "VariadicMethod", 0, gomock.InAnyOrder([]string{"a", "b"}))
+			ctrl.Call(s, "VariadicMethod", append([]any{0}, tc...)...)
+			rep.assertPass("InAnyOrder matches the variadic arguments in any order")
+		})
+	}
+}
+
+func TestVariadicNoMatchInAnyOrder(t *testing.T) {
+	rep, ctrl := createFixtures(t)
+	defer rep.recoverUnexpectedFatal()
+
+	s := new(Subject)
+	ctrl.RecordCall(s, "VariadicMethod", 0, gomock.InAnyOrder([]string{"a", "b"}))
+	rep.assertFatal(func() {
+		ctrl.Call(s, "VariadicMethod", 0, "b", "c")
+	}, "expected call at", "doesn't match the argument at index 1")
+	rep.assertFatal(func() {
+		ctrl.Call(s, "VariadicMethod", 0, "b", "a", "a")
+	}, "expected call at", "doesn't match the argument at index 1")
+	ctrl.Call(s, "VariadicMethod", 0, "b", "a")
+}
+
 func TestVariadicArgumentsGotFormatter(t *testing.T) {
 	rep, ctrl := createFixtures(t)
 	defer rep.recoverUnexpectedFatal()
@@ -842,6 +2114,23 @@ func TestWithHelper(t *testing.T) {
 	}
 }
 
+func TestReporter(t *testing.T) {
+	noHelper := NewErrorReporter(t)
+	if got := gomock.NewController(noHelper).Reporter(); got != noHelper {
+		t.Errorf("Reporter() = %v, want the reporter without Helper passed to NewController", got)
+	}
+
+	withHelper := &HelperReporter{TestReporter: NewErrorReporter(t)}
+	if got := gomock.NewController(withHelper).Reporter(); got != withHelper {
+		t.Errorf("Reporter() = %v, want the reporter with Helper passed to NewController", got)
+	}
+
+	ctrl, _ := gomock.WithContext(context.Background(), noHelper)
+	if got := ctrl.Reporter(); got != noHelper {
+		t.Errorf("Reporter() = %v, want the reporter passed to WithContext", got)
+	}
+}
+
 func (e *ErrorReporter) Cleanup(f func()) {
 	e.t.Helper()
 	e.t.Cleanup(f)
@@ -887,6 +2176,72 @@ func TestOrderedCallsInCorrect(t *testing.T) {
 	ctrl = gomock.NewController(reporter)
 }
 
+func TestAllOrNone(t *testing.T) {
+	record := func(ctrl *gomock.Controller, subject *Subject) (begin, commit *gomock.Call) {
+		begin = ctrl.RecordCall(subject, "FooMethod", "begin")
+		commit = ctrl.RecordCall(subject, "FooMethod", "commit")
+		gomock.AllOrNone(begin, commit)
+		return begin, commit
+	}
+
+	t.Run("all made", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+		record(ctrl, subject)
+
+		ctrl.Call(subject, "FooMethod", "begin")
+		ctrl.Call(subject, "FooMethod", "commit")
+		ctrl.Finish()
+		reporter.assertPass("a group whose calls were all made is satisfied")
+	})
+
+	t.Run("none made", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+		record(ctrl, subject)
+
+		if !ctrl.Satisfied() {
+			t.Error("Satisfied() = false, want true")
+		}
+		ctrl.Finish()
+		reporter.assertPass("a group none of whose calls were made is satisfied")
+	})
+
+	t.Run("partially made", func(t *testing.T) {
+		reporter, ctrl := createFixtures(t)
+		subject := new(Subject)
+		record(ctrl, subject)
+
+		ctrl.Call(subject, "FooMethod", "begin")
+		if ctrl.Satisfied() {
+			t.Error("Satisfied() = true, want false")
+		}
+		reporter.assertFatal(ctrl.Finish, "aborting test due to missing call(s)")
+		want := "missing call(s) to *gomock_test.Subject.FooMethod(is equal to commit (string))"
+		if got := reporter.log[0]; !strings.HasPrefix(got, want) || !strings.Contains(got, "AllOrNone group was partially made") {
+			t.Errorf("got failure %q, want it to report the missing commit call", got)
+		}
+	})
+}
+
+func TestAllOrNoneInvalid(t *testing.T) {
+	_, ctrl := createFixtures(t)
+	subject := new(Subject)
+	call := ctrl.RecordCall(subject, "FooMethod", "a").AnyTimes()
+	gomock.AllOrNone(call)
+
+	for _, arg := range []any{"a", call} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("AllOrNone(%v) didn't panic", arg)
+				}
+			}()
+			gomock.AllOrNone(arg)
+		}()
+	}
+}
+
 // Test that calls that are prerequisites to other calls but have maxCalls >
 // minCalls are removed from the expected call set.
 func TestOrderedCallsWithPreReqMaxUnbounded(t *testing.T) {
@@ -912,6 +2267,48 @@ func TestOrderedCallsWithPreReqMaxUnbounded(t *testing.T) {
 	ctrl = gomock.NewController(reporter)
 }
 
+func TestWithOrderingWarnings(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithOrderingWarnings())
+	subject := new(Subject)
+
+	gomock.InOrder(
+		ctrl.RecordCall(subject, "FooMethod", "1").AnyTimes(),
+		ctrl.RecordCall(subject, "FooMethod", "2"),
+	)
+	ctrl.Call(subject, "FooMethod", "1")
+	ctrl.Call(subject, "FooMethod", "2")
+
+	reporter.assertPass("warnings should not fail the test")
+	if len(reporter.log) != 1 {
+		t.Fatalf("got %d log entries, want 1: %v", len(reporter.log), reporter.log)
+	}
+	if want := `FooMethod(is equal to 1 (string))`; !strings.Contains(reporter.log[0], want) ||
+		!strings.Contains(reporter.log[0], "has an unbounded number of calls but is a prerequisite of") {
+		t.Errorf("got warning %q, want it to name %s as an unbounded prerequisite", reporter.log[0], want)
+	}
+}
+
+func TestWithOrderingWarnings_Bounded(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithOrderingWarnings())
+	subject := new(Subject)
+
+	gomock.InOrder(
+		ctrl.RecordCall(subject, "FooMethod", "1").Times(2),
+		ctrl.RecordCall(subject, "FooMethod", "2").AnyTimes(),
+	)
+	ctrl.Call(subject, "FooMethod", "1")
+	ctrl.Call(subject, "FooMethod", "1")
+	ctrl.Call(subject, "FooMethod", "2")
+	ctrl.Call(subject, "FooMethod", "2")
+
+	reporter.assertPass("bounded prerequisites")
+	if len(reporter.log) != 0 {
+		t.Errorf("got log entries %v, want none for bounded prerequisites", reporter.log)
+	}
+}
+
 func TestCallAfterLoopPanic(t *testing.T) {
 	reporter := NewErrorReporter(t)
 	subject := new(Subject)
@@ -935,3 +2332,675 @@ func TestCallAfterLoopPanic(t *testing.T) {
 	})
 	ctrl = gomock.NewController(reporter)
 }
+
+func TestCallAfterAll(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	first := ctrl.RecordCall(subject, "FooMethod", "1")
+	second := ctrl.RecordCall(subject, "BarMethod", "2")
+	ctrl.RecordCall(subject, "FooMethod", "3").AfterAll(first, second)
+
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "3")
+	}, "doesn't have a prerequisite call satisfied")
+
+	ctrl.Call(subject, "FooMethod", "1")
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "3")
+	}, "doesn't have a prerequisite call satisfied", "BarMethod")
+
+	ctrl.Call(subject, "BarMethod", "2")
+	ctrl.Call(subject, "FooMethod", "3")
+}
+
+func TestCallAfterAllLoopPanic(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	subject := new(Subject)
+	var ctrl *gomock.Controller
+	reporter.Cleanup(func() {
+		first := ctrl.RecordCall(subject, "FooMethod", "1")
+		second := ctrl.RecordCall(subject, "FooMethod", "2")
+		third := ctrl.RecordCall(subject, "FooMethod", "3")
+
+		third.AfterAll(first, second)
+
+		defer func() {
+			err := recover()
+			if err == nil {
+				t.Error("Call.AfterAll creation of dependency loop did not panic.")
+			}
+		}()
+
+		// This should panic due to dependency loop.
+		second.AfterAll(first, third)
+	})
+	ctrl = gomock.NewController(reporter)
+}
+
+type numberIs int
+
+func (n numberIs) Matches(x TestStruct) bool { return x.Number == int(n) }
+func (n numberIs) String() string            { return fmt.Sprintf("has Number %d", n) }
+
+func TestTypedMatcherWithRecordCall(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "ActOnTestStructMethod", gomock.Typed[TestStruct](numberIs(3)), 0).Return(1)
+	assertEqual(t, []any{1}, ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{Number: 3}, 0))
+	reporter.assertPass("Typed matcher passed to RecordCall")
+}
+
+func TestMapContaining(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "LabelsMethod", gomock.MapContaining(map[string]string{"env": "prod", "team": "infra"}))
+
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "LabelsMethod", map[string]string{"env": "prod", "zone": "a"})
+	}, "Unexpected call to", "doesn't match the argument at index 0", "missing key team")
+
+	ctrl.Call(subject, "LabelsMethod", map[string]string{"env": "prod", "team": "infra", "zone": "a"})
+}
+
+func TestMapContainingKeysWithCmpOpts(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithCmpOpts(cmpopts.EquateApprox(0, 0.01)))
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "SetArgMethodInterface", gomock.MapContainingKeys(1.0, 2.0), nil, nil)
+
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "SetArgMethodInterface", map[float64]bool{1.001: true, 3: true}, nil, nil)
+	}, "Unexpected call to", "doesn't match the argument at index 0", "missing key 2")
+
+	ctrl.Call(subject, "SetArgMethodInterface", map[float64]bool{1.001: true, 1.999: false}, nil, nil)
+}
+
+func TestFieldsEqUnknownFieldPanicsAtRecordTime(t *testing.T) {
+	_, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("recording FieldsEq with an unknown field did not panic")
+		}
+	}()
+	ctrl.RecordCall(subject, "ActOnTestStructMethod", gomock.FieldsEq(map[string]any{"Missing": 1}), 0)
+}
+
+func TestFieldsEqMatchesStructArgument(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "ActOnTestStructMethod", gomock.FieldsEq(map[string]any{"Number": 1}), 0).Return(7)
+	rets := ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{Number: 1, Message: "ignored"}, 0)
+	assertEqual(t, []any{7}, rets)
+	reporter.assertPass("FieldsEq should ignore unlisted fields")
+}
+
+// syncReporter is a TestReporter that is safe for concurrent use and whose
+// Fatalf doesn't stop the goroutine.
+type syncReporter struct {
+	mu     sync.Mutex
+	errors []string
+}
+
+func (r *syncReporter) Errorf(format string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *syncReporter) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+}
+
+func TestFinishConcurrently(t *testing.T) {
+	reporter := &syncReporter{}
+	ctrl := gomock.NewController(reporter)
+	subject := new(Subject)
+	ctrl.RecordCall(subject, "FooMethod", "argument")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctrl.Finish()
+		}()
+	}
+	wg.Wait()
+
+	reporter.mu.Lock()
+	defer reporter.mu.Unlock()
+	var missing, aborts int
+	for _, e := range reporter.errors {
+		switch {
+		case strings.HasPrefix(e, "missing call(s) to"):
+			missing++
+		case e == "aborting test due to missing call(s)":
+			aborts++
+		default:
+			t.Errorf("unexpected failure %q", e)
+		}
+	}
+	if missing != 1 || aborts != 1 {
+		t.Errorf("got %d missing call and %d abort failures, want exactly one of each: %v", missing, aborts, reporter.errors)
+	}
+}
+
+func TestFinishTwice(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	ctrl.Finish()
+	ctrl.Finish()
+	reporter.assertPass("a second Finish should do nothing")
+}
+
+func TestCallIntrospection(t *testing.T) {
+	_, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	once := ctrl.RecordCall(subject, "FooMethod", "1")
+	times := ctrl.RecordCall(subject, "BarMethod", "2").Times(3)
+	atLeast := ctrl.RecordCall(subject, "FooMethod", "3").MinTimes(2)
+
+	tests := []struct {
+		call     *gomock.Call
+		method   string
+		min, max int
+	}{
+		{once, "FooMethod", 1, 1},
+		{times, "BarMethod", 3, 3},
+		{atLeast, "FooMethod", 2, 1e8},
+	}
+	for _, tt := range tests {
+		if got := tt.call.Method(); got != tt.method {
+			t.Errorf("Method() = %q, want %q", got, tt.method)
+		}
+		if got := tt.call.MinCalls(); got != tt.min {
+			t.Errorf("%s: MinCalls() = %d, want %d", tt.method, got, tt.min)
+		}
+		if got := tt.call.MaxCalls(); got != tt.max {
+			t.Errorf("%s: MaxCalls() = %d, want %d", tt.method, got, tt.max)
+		}
+		if got := tt.call.NumCalls(); got != 0 {
+			t.Errorf("%s: NumCalls() = %d before any call, want 0", tt.method, got)
+		}
+	}
+
+	ctrl.Call(subject, "FooMethod", "1")
+	ctrl.Call(subject, "BarMethod", "2")
+	ctrl.Call(subject, "BarMethod", "2")
+	ctrl.Call(subject, "BarMethod", "2")
+	ctrl.Call(subject, "FooMethod", "3")
+	ctrl.Call(subject, "FooMethod", "3")
+
+	for _, tt := range tests {
+		if got := tt.call.NumCalls(); got != tt.min {
+			t.Errorf("%s: NumCalls() = %d, want %d", tt.method, got, tt.min)
+		}
+	}
+}
+
+func TestNewChild(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter)
+	child := ctrl.NewChild()
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "parent").Return(1)
+	child.RecordCall(subject, "FooMethod", "child").Return(2)
+
+	if got := child.Call(subject, "FooMethod", "child"); got[0] != 2 {
+		t.Errorf("child.Call() = %v, want 2", got[0])
+	}
+	child.Finish()
+	reporter.assertPass("the child's Finish should ignore the parent's expectations")
+
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "child")
+	}, "Unexpected call to")
+
+	reporter = NewErrorReporter(t)
+	ctrl = gomock.NewController(reporter)
+	child = ctrl.NewChild()
+	ctrl.RecordCall(subject, "FooMethod", "parent").Return(1)
+	child.RecordCall(subject, "FooMethod", "child").Return(2)
+	if got := ctrl.Call(subject, "FooMethod", "parent"); got[0] != 1 {
+		t.Errorf("ctrl.Call() = %v, want 1", got[0])
+	}
+	reporter.assertFatal(func() {
+		child.Finish()
+	}, "aborting test due to missing call(s)")
+}
+
+func TestNewChildFinishedByParent(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithCmpOpts(cmpopts.EquateApprox(0, 0.01)))
+	child := ctrl.NewChild()
+	subject := new(Subject)
+
+	child.RecordCall(subject, "FloatsMethod", gomock.InAnyOrder([]float64{1}))
+	child.RecordCall(subject, "FooMethod", "child").Return(2)
+	child.Call(subject, "FloatsMethod", []float64{1.001})
+	reporter.assertPass("the child should use the parent's options")
+
+	ctrl.Finish()
+	reporter.assertFail("the parent's Finish should report the child's missing calls")
+	if len(reporter.log) == 0 || !strings.HasPrefix(reporter.log[0], "missing call(s) to *gomock_test.Subject.FooMethod(is equal to child (string))") {
+		t.Errorf("logged %q, want the child's missing call", reporter.log)
+	}
+
+	// The child was finished along with the parent.
+	n := len(reporter.log)
+	child.Finish()
+	if len(reporter.log) != n {
+		t.Errorf("finishing the child again logged %q", reporter.log[n:])
+	}
+}
+
+func TestExpectedCallsFor(t *testing.T) {
+	_, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "1")
+	ctrl.RecordCall(subject, "FooMethod", "2").Times(2)
+	ctrl.RecordCall(subject, "BarMethod", "3")
+
+	if got := ctrl.ExpectedCallsFor(subject, "FooMethod"); got != 2 {
+		t.Errorf("ExpectedCallsFor(FooMethod) = %d, want 2", got)
+	}
+	ctrl.Call(subject, "FooMethod", "1")
+	if got := ctrl.ExpectedCallsFor(subject, "FooMethod"); got != 1 {
+		t.Errorf("ExpectedCallsFor(FooMethod) = %d after the first call, want 1", got)
+	}
+	ctrl.Call(subject, "FooMethod", "2")
+	if got := ctrl.ExpectedCallsFor(subject, "FooMethod"); got != 1 {
+		t.Errorf("ExpectedCallsFor(FooMethod) = %d with a call left to make, want 1", got)
+	}
+	ctrl.Call(subject, "FooMethod", "2")
+	if got := ctrl.ExpectedCallsFor(subject, "FooMethod"); got != 0 {
+		t.Errorf("ExpectedCallsFor(FooMethod) = %d after all calls, want 0", got)
+	}
+	if got := ctrl.ExpectedCallsFor(subject, "BarMethod"); got != 1 {
+		t.Errorf("ExpectedCallsFor(BarMethod) = %d, want 1", got)
+	}
+	if got := ctrl.ExpectedCallsFor(new(Subject), "BarMethod"); got != 0 {
+		t.Errorf("ExpectedCallsFor on another receiver = %d, want 0", got)
+	}
+	ctrl.Call(subject, "BarMethod", "3")
+}
+
+func TestWithDeferredFailures(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithDeferredFailures())
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "1").Return(1)
+
+	assertEqual(t, []any{0}, ctrl.Call(subject, "BarMethod", "2"))
+	assertEqual(t, []any{0}, ctrl.Call(subject, "FooMethod", "3"))
+	assertEqual(t, []any{1}, ctrl.Call(subject, "FooMethod", "1"))
+	reporter.assertPass("unexpected calls are not reported before Finish")
+
+	reporter.assertFatal(func() {
+		ctrl.Finish()
+	}, "aborting test due to unexpected call(s)")
+
+	if len(reporter.log) != 3 {
+		t.Fatalf("got %d log entries, want 3: %v", len(reporter.log), reporter.log)
+	}
+	if want := "Unexpected call to *gomock_test.Subject.BarMethod([2])"; !strings.HasPrefix(reporter.log[0], want) {
+		t.Errorf("got %q, want prefix %q", reporter.log[0], want)
+	}
+	if want := "Unexpected call to *gomock_test.Subject.FooMethod([3])"; !strings.HasPrefix(reporter.log[1], want) {
+		t.Errorf("got %q, want prefix %q", reporter.log[1], want)
+	}
+}
+
+func TestWithDeferredFailures_MissingCalls(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithDeferredFailures())
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "1")
+	ctrl.Call(subject, "FooMethod", "2")
+
+	reporter.assertFatal(func() {
+		ctrl.Finish()
+	}, "aborting test due to missing call(s)")
+
+	if len(reporter.log) != 3 ||
+		!strings.HasPrefix(reporter.log[0], "Unexpected call to") ||
+		!strings.HasPrefix(reporter.log[1], "missing call(s) to") {
+		t.Errorf("got log %q, want the unexpected call, then the missing call", reporter.log)
+	}
+}
+
+func TestWithForcedCleanup(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	var cleanups []func()
+	ctrl := gomock.NewController(struct{ gomock.TestReporter }{reporter}, gomock.WithForcedCleanup(func(f func()) {
+		cleanups = append(cleanups, f)
+	}))
+	subject := new(Subject)
+	ctrl.RecordCall(subject, "FooMethod", "argument")
+
+	if len(cleanups) != 1 {
+		t.Fatalf("got %d registered cleanups, want 1", len(cleanups))
+	}
+	cleanups[0]()
+	reporter.assertFail("expected call was not made")
+	if got, want := reporter.log[len(reporter.log)-1], "aborting test due to missing call(s)"; got != want {
+		t.Errorf("got last log entry %q, want %q", got, want)
+	}
+
+	// A later Finish does nothing.
+	ctrl.Finish()
+}
+
+func TestSatisfied(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "argument").Times(2)
+	ctrl.RecordCall(subject, "BarMethod", "argument").AnyTimes()
+
+	if ctrl.Satisfied() {
+		t.Error("Satisfied() = true before the minimum number of calls was made")
+	}
+	ctrl.Call(subject, "FooMethod", "argument")
+	if ctrl.Satisfied() {
+		t.Error("Satisfied() = true after 1 of 2 calls")
+	}
+	ctrl.Call(subject, "FooMethod", "argument")
+	if !ctrl.Satisfied() {
+		t.Error("Satisfied() = false after all calls were made; AnyTimes calls should count as satisfied")
+	}
+	reporter.assertPass("Satisfied should not report failures")
+}
+
+func TestHasExpectedCalls(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	if ctrl.HasExpectedCalls(subject, "FooMethod") {
+		t.Error("HasExpectedCalls() = true before any call was recorded")
+	}
+	ctrl.RecordCall(subject, "FooMethod", "argument").Return(1)
+	if !ctrl.HasExpectedCalls(subject, "FooMethod") {
+		t.Error("HasExpectedCalls() = false after a call was recorded")
+	}
+	if ctrl.HasExpectedCalls(subject, "BarMethod") {
+		t.Error("HasExpectedCalls() = true for a method without recorded calls")
+	}
+	if ctrl.HasExpectedCalls(new(Subject), "FooMethod") {
+		t.Error("HasExpectedCalls() = true for another receiver")
+	}
+	ctrl.Call(subject, "FooMethod", "argument")
+	if !ctrl.HasExpectedCalls(subject, "FooMethod") {
+		t.Error("HasExpectedCalls() = false after the recorded call was exhausted")
+	}
+	reporter.assertPass("HasExpectedCalls should not report failures")
+}
+
+type structuredReporter struct {
+	*ErrorReporter
+	failures []gomock.Failure
+}
+
+func (r *structuredReporter) ReportFailure(f gomock.Failure) {
+	r.failures = append(r.failures, f)
+}
+
+func TestStructuredReporter(t *testing.T) {
+	reporter := &structuredReporter{ErrorReporter: NewErrorReporter(t)}
+	ctrl := gomock.NewController(reporter)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "ActOnTestStructMethod", TestStruct{Number: 1}, 2)
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{Number: 1}, 3)
+	}, "Unexpected call to")
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "argument")
+	}, "there are no expected calls")
+
+	if len(reporter.failures) != 2 {
+		t.Fatalf("got %d structured failures, want 2", len(reporter.failures))
+	}
+	got := reporter.failures[0]
+	if got.Receiver != subject || got.Method != "ActOnTestStructMethod" {
+		t.Errorf("got failure for %T.%v, want *Subject.ActOnTestStructMethod", got.Receiver, got.Method)
+	}
+	if got.ArgIndex != 1 || got.Want != "is equal to 2 (int)" || got.Got != 3 {
+		t.Errorf("got ArgIndex=%d Want=%q Got=%v, want ArgIndex=1 Want=%q Got=3", got.ArgIndex, got.Want, got.Got, "is equal to 2 (int)")
+	}
+	assertEqual(t, []any{TestStruct{Number: 1}, 3}, got.Args)
+	if !strings.Contains(got.Message, "doesn't match the argument at index 1") {
+		t.Errorf("got Message %q, want it to explain the mismatch", got.Message)
+	}
+
+	if got := reporter.failures[1]; got.Method != "FooMethod" || got.ArgIndex != -1 || got.Want != "" || got.Got != nil {
+		t.Errorf("got %+v, want a failure for FooMethod without an argument index", got)
+	}
+
+	ctrl.Call(subject, "ActOnTestStructMethod", TestStruct{Number: 1}, 2)
+}
+
+func TestReset(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "first").Return(1)
+	assertEqual(t, []any{1}, ctrl.Call(subject, "FooMethod", "first"))
+	ctrl.RecordCall(subject, "BarMethod", "pending")
+	ctrl.Reset()
+
+	ctrl.RecordCall(subject, "FooMethod", "second").Return(2)
+	assertEqual(t, []any{2}, ctrl.Call(subject, "FooMethod", "second"))
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "FooMethod", "first")
+	}, "Unexpected call to", "doesn't match the argument at index 0")
+	reporter.failed = false
+
+	ctrl.Finish()
+	reporter.assertPass("Finish after Reset should only check new expectations")
+
+	// Finish can be called again after Reset.
+	ctrl.Reset()
+	ctrl.RecordCall(subject, "FooMethod", "third")
+	reporter.assertFatal(func() {
+		ctrl.Finish()
+	}, "aborting test due to missing call(s)")
+}
+
+func TestWithObserver(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	var infos []gomock.CallInfo
+	ctrl := gomock.NewController(reporter, gomock.WithObserver(func(info gomock.CallInfo) {
+		infos = append(infos, info)
+	}))
+	subject := new(Subject)
+
+	expected := ctrl.RecordCall(subject, "FooMethod", "argument")
+	ctrl.Call(subject, "FooMethod", "argument")
+	reporter.assertFatal(func() {
+		ctrl.Call(subject, "BarMethod", "unexpected")
+	})
+
+	if len(infos) != 2 {
+		t.Fatalf("observer called %d times, want 2", len(infos))
+	}
+	assertEqual(t, gomock.CallInfo{Receiver: subject, Method: "FooMethod", Args: []any{"argument"}, Call: expected}, infos[0])
+	assertEqual(t, gomock.CallInfo{Receiver: subject, Method: "BarMethod", Args: []any{"unexpected"}}, infos[1])
+}
+
+func TestFinishWithTimeout(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "argument")
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		time.Sleep(20 * time.Millisecond)
+		ctrl.Call(subject, "FooMethod", "argument")
+	}()
+
+	ctrl.FinishWithTimeout(time.Second)
+	reporter.assertPass("Expectation satisfied by a background goroutine")
+	<-done
+}
+
+func TestFinishWithTimeoutReturnsEarly(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "argument")
+	ctrl.Call(subject, "FooMethod", "argument")
+
+	start := time.Now()
+	ctrl.FinishWithTimeout(time.Minute)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("FinishWithTimeout took %v for satisfied expectations", elapsed)
+	}
+	reporter.assertPass("Expectations already satisfied")
+}
+
+func TestFinishWithTimeoutExpires(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "argument")
+	reporter.assertFatal(func() {
+		ctrl.FinishWithTimeout(10 * time.Millisecond)
+	}, "aborting test due to missing call(s)")
+}
+
+func TestWithStrictOrdering(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithStrictOrdering())
+	subjectOne := new(Subject)
+	subjectTwo := new(Subject)
+
+	ctrl.RecordCall(subjectOne, "FooMethod", "1")
+	ctrl.RecordCall(subjectTwo, "BarMethod", "2")
+	ctrl.RecordCall(subjectOne, "BarMethod", "3")
+
+	ctrl.Call(subjectOne, "FooMethod", "1")
+	ctrl.Call(subjectTwo, "BarMethod", "2")
+	ctrl.Call(subjectOne, "BarMethod", "3")
+	ctrl.Finish()
+
+	reporter.assertPass("Calls made in recorded order")
+}
+
+func TestWithStrictOrderingOutOfOrder(t *testing.T) {
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter, gomock.WithStrictOrdering())
+	subjectOne := new(Subject)
+	subjectTwo := new(Subject)
+
+	ctrl.RecordCall(subjectOne, "FooMethod", "1")
+	ctrl.RecordCall(subjectTwo, "BarMethod", "2")
+
+	reporter.assertFatal(func() {
+		ctrl.Call(subjectTwo, "BarMethod", "2")
+	}, "Unexpected call to *gomock_test.Subject.BarMethod", "doesn't have a prerequisite call satisfied:",
+		"*gomock_test.Subject.FooMethod(is equal to 1 (string))", "should be called before:")
+}
+
+func TestUnsatisfiedCalls(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "FooMethod", "argument").Times(3)
+	ctrl.RecordCall(subject, "BarMethod", gomock.Any())
+	ctrl.RecordCall(subject, "BarMethod", "optional").AnyTimes()
+
+	ctrl.Call(subject, "FooMethod", "argument")
+
+	got := ctrl.UnsatisfiedCalls()
+	if len(got) != 2 {
+		t.Fatalf("UnsatisfiedCalls() returned %d entries, want 2: %q", len(got), got)
+	}
+	wants := []struct{ prefix, suffix string }{
+		{"*gomock_test.Subject.BarMethod(is anything) ", " (1 more call(s) expected)"},
+		{"*gomock_test.Subject.FooMethod(is equal to argument (string)) ", " (2 more call(s) expected)"},
+	}
+	for i, want := range wants {
+		if !strings.HasPrefix(got[i], want.prefix) || !strings.HasSuffix(got[i], want.suffix) {
+			t.Errorf("UnsatisfiedCalls()[%d] = %q, want %q...%q", i, got[i], want.prefix, want.suffix)
+		}
+	}
+
+	ctrl.Call(subject, "FooMethod", "argument")
+	ctrl.Call(subject, "FooMethod", "argument")
+	ctrl.Call(subject, "BarMethod", "x")
+	if got := ctrl.UnsatisfiedCalls(); len(got) != 0 {
+		t.Errorf("UnsatisfiedCalls() = %q after all calls were made, want none", got)
+	}
+	reporter.assertPass("UnsatisfiedCalls should not report failures")
+}
+
+type typedNilError struct{}
+
+func (*typedNilError) Error() string { return "typed nil" }
+
+func TestNilMatchesTypedNilInterfaceArgument(t *testing.T) {
+	reporter, ctrl := createFixtures(t)
+	subject := new(Subject)
+
+	ctrl.RecordCall(subject, "ErrorMethod", gomock.Nil())
+	ctrl.RecordCall(subject, "ErrorMethod", nil)
+
+	var typedNil *typedNilError
+	var err error = typedNil
+	ctrl.Call(subject, "ErrorMethod", err)
+	ctrl.Call(subject, "ErrorMethod", err)
+	ctrl.Finish()
+
+	reporter.assertPass("Nil should match a typed nil passed as an error")
+}
+
+func BenchmarkControllerCall(b *testing.B) {
+	ctrl := gomock.NewController(b)
+	subject := new(Subject)
+	ctrl.RecordCall(subject, "ActOnTestStructMethod", gomock.Any(), gomock.Any()).AnyTimes()
+	arg := TestStruct{Number: 1, Message: "hello"}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ctrl.Call(subject, "ActOnTestStructMethod", arg, 1)
+	}
+}
+
+func BenchmarkControllerRecordCall(b *testing.B) {
+	ctrl := gomock.NewController(b)
+	subject := new(Subject)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ctrl.RecordCall(subject, "FooMethod", "argument").Return(1)
+		ctrl.Call(subject, "FooMethod", "argument")
+		ctrl.Reset()
+	}
+}
+
+func BenchmarkControllerCallNoArgs(b *testing.B) {
+	ctrl := gomock.NewController(b)
+	subject := new(Subject)
+	ctrl.RecordCall(subject, "CountMethod").Return(1, nil).AnyTimes()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ctrl.Call(subject, "CountMethod")
+	}
+}
@@ -15,12 +15,20 @@
 package gomock
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"go/token"
 	"reflect"
 	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 )
 
 // A Matcher is a representation of a class of values.
@@ -33,6 +41,21 @@ type Matcher interface {
 	String() string
 }
 
+// A TypedMatcher is a type-safe Matcher for values of type T. Use Typed to
+// turn it into a Matcher that can be passed to a mock's recorder.
+type TypedMatcher[T any] interface {
+	// Matches returns whether x is a match.
+	Matches(x T) bool
+
+	// String describes what the matcher matches.
+	String() string
+}
+
+// Differ is implemented by matchers that can describe why a value doesn't
+// match. If a matcher implements Differ, failure messages show the result of
+// Diff instead of the received value, which lets custom matchers replace a
+// large go-cmp diff with a compact, domain-specific summary. opts are the
+// cmp.Options of the Controller and can be ignored by such matchers.
 type Differ interface {
 	// Diff shows the difference between the value and x.
 	Diff(x interface{}, opts ...cmp.Option) string
@@ -95,6 +118,17 @@ func GotFormatterAdapter(s GotFormatter, m Matcher) Matcher {
 	}
 }
 
+// WithGotFormat returns a matcher that matches exactly like m, but uses fn to
+// render the received value in failure messages. It is shorthand for
+// GotFormatterAdapter(GotFormatterFunc(fn), m).
+//
+// Example usage:
+//
+//	WithGotFormat(Eq(15), func(x any) string { return fmt.Sprintf("%02d", x) })
+func WithGotFormat(m Matcher, fn func(any) string) Matcher {
+	return GotFormatterAdapter(GotFormatterFunc(fn), m)
+}
+
 type anyMatcher struct{}
 
 func (anyMatcher) Matches(any) bool {
@@ -109,6 +143,24 @@ func (anyMatcher) String() string {
 	return "is anything"
 }
 
+type anyLogMatcher struct {
+	t TestReporter
+}
+
+func (m anyLogMatcher) Matches(x any) bool {
+	if h, ok := m.t.(TestHelper); ok {
+		h.Helper()
+	}
+	if l, ok := m.t.(logger); ok {
+		l.Logf("gomock: AnyLog received %v (%T)", x, x)
+	}
+	return true
+}
+
+func (anyLogMatcher) String() string {
+	return "is anything"
+}
+
 type condMatcher struct {
 	fn func(x any) bool
 }
@@ -144,13 +196,118 @@ func (e eqMatcher) Matches(x any) bool {
 }
 
 func (e eqMatcher) Diff(x interface{}, opts ...cmp.Option) string {
+	want, got := reflect.ValueOf(e.x), reflect.ValueOf(x)
+	if want.Kind() == reflect.Map && got.IsValid() && got.Type() == want.Type() {
+		// Maps with the same entries may still differ, e.g. nil and empty.
+		if diff := mapDiff(want, got, opts); diff != "" {
+			return diff
+		}
+	}
 	return cmp.Diff(e.x, x, opts...)
 }
 
+// mapDiff describes the differences between the maps want and got, of the
+// same type, listing only the keys that are missing from got, unexpected in
+// got, or whose values differ, sorted by key.
+func mapDiff(want, got reflect.Value, opts cmp.Options) string {
+	type keyDiff struct{ key, diff string }
+	var diffs []keyDiff
+	for _, k := range want.MapKeys() {
+		key := getString(k.Interface())
+		wv, gv := want.MapIndex(k), got.MapIndex(k)
+		switch {
+		case !gv.IsValid():
+			diffs = append(diffs, keyDiff{key, fmt.Sprintf("missing key %s: want: %s", key, getString(wv.Interface()))})
+		case !cmp.Equal(wv.Interface(), gv.Interface(), opts):
+			diffs = append(diffs, keyDiff{key, fmt.Sprintf("%s: got: %s, want: %s", key, getString(gv.Interface()), getString(wv.Interface()))})
+		}
+	}
+	for _, k := range got.MapKeys() {
+		if !want.MapIndex(k).IsValid() {
+			key := getString(k.Interface())
+			diffs = append(diffs, keyDiff{key, fmt.Sprintf("unexpected key %s: got: %s", key, getString(got.MapIndex(k).Interface()))})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].key < diffs[j].key })
+
+	var sb strings.Builder
+	for _, d := range diffs {
+		sb.WriteString("\n" + d.diff)
+	}
+	return sb.String()
+}
+
 func (e eqMatcher) String() string {
 	return fmt.Sprintf("is equal to %s (%T)", getString(e.x), e.x)
 }
 
+type eqOptsMatcher struct {
+	x    any
+	opts cmp.Options
+	base cmp.Options // the Controller's options
+	// ignored lists the fields ignored by EqIgnoring, for String.
+	ignored []string
+}
+
+func (e eqOptsMatcher) Matches(x any) bool {
+	return cmp.Equal(e.x, x, e.base, e.opts)
+}
+
+func (e eqOptsMatcher) Diff(x interface{}, opts ...cmp.Option) string {
+	return cmp.Diff(e.x, x, cmp.Options(opts), e.opts)
+}
+
+func (e eqOptsMatcher) String() string {
+	if len(e.ignored) > 0 {
+		return fmt.Sprintf("is equal to %s (%T) ignoring fields %s", getString(e.x), e.x, strings.Join(e.ignored, ", "))
+	}
+	return fmt.Sprintf("is equal to %s (%T)", getString(e.x), e.x)
+}
+
+func (e eqOptsMatcher) withBaseCmpOpts(base cmp.Options) Matcher {
+	e.base = base
+	return e
+}
+
+// equalWithBase reports whether got equals wanted. Values are compared like
+// Eq does, unless the Controller has cmp options, in which case they are
+// compared with cmp.Equal using those options.
+func equalWithBase(wanted, got any, base cmp.Options) bool {
+	if len(base) > 0 {
+		return cmp.Equal(wanted, got, base)
+	}
+	return Eq(wanted).Matches(got)
+}
+
+type equalMethodMatcher struct {
+	x    any
+	base cmp.Options
+}
+
+func (e equalMethodMatcher) Matches(x any) bool {
+	v := reflect.ValueOf(x)
+	if v.IsValid() && !(v.Kind() == reflect.Ptr && v.IsNil()) {
+		if eq := v.MethodByName("Equal"); eq.IsValid() {
+			mt := eq.Type()
+			want := reflect.ValueOf(e.x)
+			if mt.NumIn() == 1 && mt.NumOut() == 1 && mt.Out(0).Kind() == reflect.Bool &&
+				want.IsValid() && want.Type().AssignableTo(mt.In(0)) {
+				return eq.Call(funcArgs(mt, []any{e.x}))[0].Bool()
+			}
+		}
+	}
+	return equalWithBase(e.x, x, e.base)
+}
+
+func (e equalMethodMatcher) String() string {
+	return "equals (via Equal method) " + getString(e.x)
+}
+
+func (e equalMethodMatcher) withBaseCmpOpts(base cmp.Options) Matcher {
+	e.base = base
+	return e
+}
+
 type nilMatcher struct{}
 
 func (nilMatcher) Matches(x any) bool {
@@ -158,10 +315,13 @@ func (nilMatcher) Matches(x any) bool {
 		return true
 	}
 
+	// x is a non-nil interface value. It still counts as nil if its dynamic
+	// value is a nil pointer, map, slice, chan or func, e.g. a (*T)(nil)
+	// passed as an error.
 	v := reflect.ValueOf(x)
 	switch v.Kind() {
 	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map,
-		reflect.Ptr, reflect.Slice:
+		reflect.Ptr, reflect.Slice, reflect.UnsafePointer:
 		return v.IsNil()
 	}
 
@@ -176,6 +336,171 @@ func (nilMatcher) String() string {
 	return "is nil"
 }
 
+type notNilMatcher struct{}
+
+func (notNilMatcher) Matches(x any) bool {
+	return !nilMatcher{}.Matches(x)
+}
+
+func (notNilMatcher) String() string {
+	return "is not nil"
+}
+
+type sameMatcher struct {
+	x any
+}
+
+func (m sameMatcher) Matches(x any) bool {
+	if reflect.TypeOf(x) != reflect.TypeOf(m.x) {
+		return false
+	}
+	return reflect.ValueOf(x).Pointer() == reflect.ValueOf(m.x).Pointer()
+}
+
+func (m sameMatcher) String() string {
+	return fmt.Sprintf("is the same instance as %p (%T)", m.x, m.x)
+}
+
+type notCancelledContextMatcher struct{}
+
+func (notCancelledContextMatcher) Matches(x any) bool {
+	ctx, ok := x.(context.Context)
+	return ok && ctx != nil && ctx.Err() == nil
+}
+
+func (notCancelledContextMatcher) String() string {
+	return "is a live (non-cancelled) context"
+}
+
+type deadlineWithinMatcher struct {
+	d time.Duration
+}
+
+func (m deadlineWithinMatcher) Matches(x any) bool {
+	ctx, ok := x.(context.Context)
+	if !ok || ctx == nil {
+		return false
+	}
+	deadline, ok := ctx.Deadline()
+	return ok && time.Until(deadline) <= m.d
+}
+
+func (m deadlineWithinMatcher) Diff(x interface{}, opts ...cmp.Option) string {
+	ctx, ok := x.(context.Context)
+	if !ok || ctx == nil {
+		return fmt.Sprintf("%v (%T) is not a context", x, x)
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return "context has no deadline"
+	}
+	return fmt.Sprintf("context deadline is %v from now", time.Until(deadline).Round(time.Millisecond))
+}
+
+func (m deadlineWithinMatcher) String() string {
+	return fmt.Sprintf("has a deadline within %v", m.d)
+}
+
+type timeEqMatcher struct {
+	t         time.Time // without monotonic clock reading
+	tolerance time.Duration
+}
+
+func (m timeEqMatcher) Matches(x any) bool {
+	t, ok := x.(time.Time)
+	if !ok {
+		return false
+	}
+	d := t.Round(0).Sub(m.t)
+	return -m.tolerance <= d && d <= m.tolerance
+}
+
+func (m timeEqMatcher) String() string {
+	return fmt.Sprintf("is within %v of %v", m.tolerance, m.t)
+}
+
+type durationApproxMatcher struct {
+	d, tolerance time.Duration
+}
+
+func (m durationApproxMatcher) Matches(x any) bool {
+	d, ok := x.(time.Duration)
+	if !ok {
+		return false
+	}
+	diff := d - m.d
+	return -m.tolerance <= diff && diff <= m.tolerance
+}
+
+func (m durationApproxMatcher) String() string {
+	return fmt.Sprintf("is within %v of %v", m.tolerance, m.d)
+}
+
+type emptyMatcher struct{}
+
+func (emptyMatcher) Matches(x any) bool {
+	if x == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(x)
+	switch v.Kind() {
+	case reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
+
+func (emptyMatcher) String() string {
+	return "is empty"
+}
+
+type notEmptyMatcher struct{}
+
+func (notEmptyMatcher) Matches(x any) bool {
+	return !emptyMatcher{}.Matches(x)
+}
+
+func (notEmptyMatcher) String() string {
+	return "is not empty"
+}
+
+type stringerEqMatcher struct {
+	s string
+}
+
+func (m stringerEqMatcher) Matches(x any) bool {
+	s, ok := x.(fmt.Stringer)
+	return ok && s.String() == m.s
+}
+
+func (m stringerEqMatcher) Got(got any) string {
+	if s, ok := got.(fmt.Stringer); ok {
+		return fmt.Sprintf("%q (%T)", s.String(), got)
+	}
+	return fmt.Sprintf("%v (%T), which is not a fmt.Stringer", got, got)
+}
+
+func (m stringerEqMatcher) String() string {
+	return fmt.Sprintf("stringer equals %q", m.s)
+}
+
+type truthyMatcher struct {
+	truthy bool
+}
+
+func (m truthyMatcher) Matches(x any) bool {
+	return emptyMatcher{}.Matches(x) != m.truthy
+}
+
+func (m truthyMatcher) String() string {
+	if m.truthy {
+		return "is truthy"
+	}
+	return "is falsy"
+}
+
 type notMatcher struct {
 	m Matcher
 }
@@ -188,6 +513,19 @@ func (n notMatcher) String() string {
 	return "not(" + n.m.String() + ")"
 }
 
+type whenMatcher struct {
+	cond func() bool
+	m    Matcher
+}
+
+func (w whenMatcher) Matches(x any) bool {
+	return w.cond() && w.m.Matches(x)
+}
+
+func (w whenMatcher) String() string {
+	return "when custom condition then " + w.m.String()
+}
+
 type regexMatcher struct {
 	regex *regexp.Regexp
 }
@@ -207,20 +545,79 @@ func (m regexMatcher) String() string {
 	return "matches regex " + m.regex.String()
 }
 
+type equalFoldMatcher struct {
+	s string
+}
+
+func (m equalFoldMatcher) Matches(x any) bool {
+	switch t := x.(type) {
+	case string:
+		return strings.EqualFold(t, m.s)
+	case []byte:
+		return strings.EqualFold(string(t), m.s)
+	default:
+		return false
+	}
+}
+
+func (m equalFoldMatcher) String() string {
+	return fmt.Sprintf("equals (case-insensitive) %q", m.s)
+}
+
 type assignableToTypeOfMatcher struct {
 	targetType reflect.Type
 }
 
 func (m assignableToTypeOfMatcher) Matches(x any) bool {
-	return reflect.TypeOf(x).AssignableTo(m.targetType)
+	xt := reflect.TypeOf(x)
+	if xt == nil {
+		// An untyped nil carries no dynamic type to check.
+		return false
+	}
+	return xt.AssignableTo(m.targetType)
 }
 
-func (m assignableToTypeOfMatcher) Diff(x interface{}, opts ...cmp.Option) string {
-	return cmp.Diff(m.targetType, reflect.TypeOf(x), opts...)
+// Got renders the type of x, so that failures name both the got and the
+// wanted type.
+func (m assignableToTypeOfMatcher) Got(x any) string {
+	return fmt.Sprintf("%v", reflect.TypeOf(x))
 }
 
 func (m assignableToTypeOfMatcher) String() string {
-	return "is assignable to " + m.targetType.Name()
+	return "is assignable to " + m.targetType.String()
+}
+
+type anyOfTypeMatcher struct {
+	t reflect.Type
+}
+
+func (m anyOfTypeMatcher) Matches(x any) bool {
+	xt := reflect.TypeOf(x)
+	if xt == nil {
+		return false
+	}
+	if m.t.Kind() == reflect.Interface {
+		return xt.Implements(m.t)
+	}
+	return xt == m.t
+}
+
+func (m anyOfTypeMatcher) String() string {
+	return "is any " + m.t.String()
+}
+
+type anyChanMatcher struct {
+	elem reflect.Type
+	dir  reflect.ChanDir
+}
+
+func (m anyChanMatcher) Matches(x any) bool {
+	xt := reflect.TypeOf(x)
+	return xt != nil && xt.Kind() == reflect.Chan && xt.Elem() == m.elem && xt.ChanDir() == m.dir
+}
+
+func (m anyChanMatcher) String() string {
+	return "is a " + reflect.ChanOf(m.dir, m.elem).String()
 }
 
 type anyOfMatcher struct {
@@ -305,8 +702,36 @@ func (m lenMatcher) String() string {
 	return fmt.Sprintf("has length %d", m.i)
 }
 
+type lenMatchingMatcher struct {
+	m Matcher
+}
+
+func (m lenMatchingMatcher) Matches(x any) bool {
+	v := reflect.ValueOf(x)
+	switch v.Kind() {
+	case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
+		return m.m.Matches(v.Len())
+	default:
+		return false
+	}
+}
+
+func (m lenMatchingMatcher) String() string {
+	return "has length matching " + m.m.String()
+}
+
 type inAnyOrderMatcher struct {
-	x any
+	x    any
+	base cmp.Options
+}
+
+func (m inAnyOrderMatcher) elemMatches(wanted, given any) bool {
+	return equalWithBase(wanted, given, m.base)
+}
+
+func (m inAnyOrderMatcher) withBaseCmpOpts(base cmp.Options) Matcher {
+	m.base = base
+	return m
 }
 
 func (m inAnyOrderMatcher) Matches(x any) bool {
@@ -326,12 +751,11 @@ func (m inAnyOrderMatcher) Matches(x any) bool {
 	usedFromGiven := make([]bool, given.Len())
 	foundFromWanted := make([]bool, wanted.Len())
 	for i := 0; i < wanted.Len(); i++ {
-		wantedMatcher := Eq(wanted.Index(i).Interface())
 		for j := 0; j < given.Len(); j++ {
 			if usedFromGiven[j] {
 				continue
 			}
-			if wantedMatcher.Matches(given.Index(j).Interface()) {
+			if m.elemMatches(wanted.Index(i).Interface(), given.Index(j).Interface()) {
 				foundFromWanted[i] = true
 				usedFromGiven[j] = true
 				break
@@ -415,71 +839,818 @@ func (m inAnyOrderMatcher) String() string {
 	return fmt.Sprintf("has the same elements as %v", m.x)
 }
 
-// Constructors
+type sortedMatcher struct {
+	less func(a, b any) bool
+}
 
-// All returns a composite Matcher that returns true if and only all of the
-// matchers return true.
-func All(ms ...Matcher) Matcher { return allMatcher{ms} }
+func (m sortedMatcher) Matches(x any) bool {
+	v := reflect.ValueOf(x)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return false
+	}
+	for i := 1; i < v.Len(); i++ {
+		if m.less(v.Index(i).Interface(), v.Index(i-1).Interface()) {
+			return false
+		}
+	}
+	return true
+}
 
-// Any returns a matcher that always matches.
-func Any() Matcher { return anyMatcher{} }
+func (m sortedMatcher) String() string {
+	return "is sorted"
+}
 
-// Cond returns a matcher that matches when the given function returns true
-// after passing it the parameter to the mock function.
-// This is particularly useful in case you want to match over a field of a custom struct, or dynamic logic.
-//
-// Example usage:
-//
-//	Cond(func(x any){return x.(int) == 1}).Matches(1) // returns true
-//	Cond(func(x any){return x.(int) == 2}).Matches(1) // returns false
-func Cond(fn func(x any) bool) Matcher { return condMatcher{fn} }
+type sameFuncMatcher struct {
+	fn reflect.Value
+}
 
-// AnyOf returns a composite Matcher that returns true if at least one of the
-// matchers returns true.
-//
-// Example usage:
-//
-//	AnyOf(1, 2, 3).Matches(2) // returns true
-//	AnyOf(1, 2, 3).Matches(10) // returns false
-//	AnyOf(Nil(), Len(2)).Matches(nil) // returns true
-//	AnyOf(Nil(), Len(2)).Matches("hi") // returns true
-//	AnyOf(Nil(), Len(2)).Matches("hello") // returns false
-func AnyOf(xs ...any) Matcher {
-	ms := make([]Matcher, 0, len(xs))
-	for _, x := range xs {
-		if m, ok := x.(Matcher); ok {
-			ms = append(ms, m)
-		} else {
-			ms = append(ms, Eq(x))
-		}
+func (m sameFuncMatcher) Matches(x any) bool {
+	v := reflect.ValueOf(x)
+	if v.Kind() != reflect.Func || v.Type() != m.fn.Type() || v.IsNil() {
+		return false
 	}
-	return anyOfMatcher{ms}
+	return v.Pointer() == m.fn.Pointer()
 }
 
-// Eq returns a matcher that matches on equality.
-//
-// Example usage:
-//
-//	Eq(5).Matches(5) // returns true
-//	Eq(5).Matches(4) // returns false
-func Eq(x any) Matcher { return eqMatcher{x} }
+func (m sameFuncMatcher) String() string {
+	return "is the same function"
+}
 
-// Len returns a matcher that matches on length. This matcher returns false if
-// is compared to a type that is not an array, chan, map, slice, or string.
-func Len(i int) Matcher {
-	return lenMatcher{i}
+type jsonEqMatcher struct {
+	want    any
+	wantErr error
+	raw     string
 }
 
-// Nil returns a matcher that matches if the received value is nil.
-//
-// Example usage:
+func (m jsonEqMatcher) Matches(x any) bool {
+	if m.wantErr != nil {
+		return false
+	}
+	got, err := unmarshalJSONArg(x)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(m.want, got)
+}
+
+func (m jsonEqMatcher) Diff(x interface{}, opts ...cmp.Option) string {
+	got, err := unmarshalJSONArg(x)
+	if err != nil {
+		return fmt.Sprintf("invalid JSON argument: %v", err)
+	}
+	return cmp.Diff(m.want, got, opts...)
+}
+
+func (m jsonEqMatcher) String() string {
+	if m.wantErr != nil {
+		return fmt.Sprintf("is JSON equal to %s (invalid JSON: %v)", m.raw, m.wantErr)
+	}
+	canonical, err := json.Marshal(m.want)
+	if err != nil {
+		return "is JSON equal to " + m.raw
+	}
+	return "is JSON equal to " + string(canonical)
+}
+
+type protoEqMatcher[M any] struct {
+	msg   M
+	equal func(x, y M) bool
+}
+
+func (m protoEqMatcher[M]) Matches(x any) bool {
+	got, ok := x.(M)
+	return ok && m.equal(m.msg, got)
+}
+
+func (m protoEqMatcher[M]) Got(got any) string {
+	return fmt.Sprintf("{%v} (%T)", getString(got), got)
+}
+
+func (m protoEqMatcher[M]) String() string {
+	return fmt.Sprintf("is equal to {%v} (%T)", getString(m.msg), m.msg)
+}
+
+// unmarshalJSONArg decodes a string, []byte or json.RawMessage argument into
+// a generic value.
+func unmarshalJSONArg(x any) (any, error) {
+	var data []byte
+	switch t := x.(type) {
+	case string:
+		data = []byte(t)
+	case []byte:
+		data = t
+	case json.RawMessage:
+		data = t
+	default:
+		return nil, fmt.Errorf("unsupported type %T", x)
+	}
+	var v any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("unexpected data after top-level JSON value")
+	}
+	return v, nil
+}
+
+type errorIsMatcher struct {
+	target error
+}
+
+func (m errorIsMatcher) Matches(x any) bool {
+	if x == nil {
+		return m.target == nil
+	}
+	err, ok := x.(error)
+	if !ok {
+		return false
+	}
+	return errors.Is(err, m.target)
+}
+
+func (m errorIsMatcher) String() string {
+	return fmt.Sprintf("is an error matching %q", fmt.Sprint(m.target))
+}
+
+type errorContainsMatcher struct {
+	substr string
+}
+
+func (m errorContainsMatcher) Matches(x any) bool {
+	// A (*T)(nil) error is rejected too, since calling its Error method
+	// would most likely panic.
+	if (nilMatcher{}).Matches(x) {
+		return false
+	}
+	err, ok := x.(error)
+	if !ok {
+		return false
+	}
+	return strings.Contains(err.Error(), m.substr)
+}
+
+func (m errorContainsMatcher) String() string {
+	return fmt.Sprintf("is an error containing %q", m.substr)
+}
+
+type errorAsMatcher struct {
+	target any
+}
+
+func (m errorAsMatcher) Matches(x any) bool {
+	err, ok := x.(error)
+	if !ok {
+		return false
+	}
+	return errors.As(err, m.target)
+}
+
+func (m errorAsMatcher) String() string {
+	return fmt.Sprintf("is an error assignable to %v", reflect.TypeOf(m.target).Elem())
+}
+
+type implementsMatcher struct {
+	iface reflect.Type
+}
+
+func (m implementsMatcher) Matches(x any) bool {
+	xt := reflect.TypeOf(x)
+	if xt == nil {
+		return false
+	}
+	return xt.Implements(m.iface)
+}
+
+func (m implementsMatcher) String() string {
+	return "implements " + m.iface.String()
+}
+
+type pointeeMatcher struct {
+	m Matcher
+}
+
+func (m pointeeMatcher) Matches(x any) bool {
+	v := reflect.ValueOf(x)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return false
+	}
+	return m.m.Matches(v.Elem().Interface())
+}
+
+func (m pointeeMatcher) String() string {
+	return "points to " + m.m.String()
+}
+
+type fieldsEqMatcher struct {
+	names  []string // sorted field names
+	fields map[string]Matcher
+}
+
+func (m fieldsEqMatcher) Matches(x any) bool {
+	v, ok := m.structValue(x)
+	if !ok {
+		return false
+	}
+	for _, name := range m.names {
+		f, err := fieldByName(v, name)
+		if err != nil || !f.IsValid() || !m.fields[name].Matches(f.Interface()) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m fieldsEqMatcher) Diff(x interface{}, opts ...cmp.Option) string {
+	v, ok := m.structValue(x)
+	if !ok {
+		return fmt.Sprintf("got %v (%T), want a struct or pointer to struct", x, x)
+	}
+	var sb strings.Builder
+	for _, name := range m.names {
+		f, err := fieldByName(v, name)
+		if err != nil {
+			fmt.Fprintf(&sb, "\n%s: %v", name, err)
+			continue
+		}
+		if !f.IsValid() {
+			fmt.Fprintf(&sb, "\n%s: missing from %T", name, x)
+			continue
+		}
+		if got := f.Interface(); !m.fields[name].Matches(got) {
+			fmt.Fprintf(&sb, "\n%s: got: %s, want: %v", name, formatGottenArg(m.fields[name], got), m.fields[name])
+		}
+	}
+	return sb.String()
+}
+
+func (m fieldsEqMatcher) String() string {
+	ss := make([]string, 0, len(m.names))
+	for _, name := range m.names {
+		ss = append(ss, name+": "+m.fields[name].String())
+	}
+	return "has fields {" + strings.Join(ss, ", ") + "}"
+}
+
+// fieldByName is like v.FieldByName, but returns an error instead of
+// panicking if the field is promoted through a nil embedded pointer.
+func fieldByName(v reflect.Value, name string) (reflect.Value, error) {
+	f, ok := v.Type().FieldByName(name)
+	if !ok {
+		return reflect.Value{}, nil
+	}
+	return v.FieldByIndexErr(f.Index)
+}
+
+// checkArgType panics if t is a struct, or pointer to struct, type lacking
+// any of the compared fields.
+func (m fieldsEqMatcher) checkArgType(t reflect.Type) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+	for _, name := range m.names {
+		if _, ok := t.FieldByName(name); !ok {
+			panic(fmt.Sprintf("gomock: FieldsEq: %v has no field %q", t, name))
+		}
+	}
+}
+
+func (fieldsEqMatcher) structValue(x any) (reflect.Value, bool) {
+	v := reflect.ValueOf(x)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	return v, v.Kind() == reflect.Struct
+}
+
+type fieldMatcher struct {
+	path string
+	m    Matcher
+}
+
+func (m fieldMatcher) Matches(x any) bool {
+	v, err := m.resolve(x)
+	return err == nil && m.m.Matches(v)
+}
+
+func (m fieldMatcher) Diff(x interface{}, opts ...cmp.Option) string {
+	v, err := m.resolve(x)
+	if err != nil {
+		return err.Error()
+	}
+	return fmt.Sprintf("%s: got: %s, want: %v", m.path, formatGottenArg(m.m, v), m.m)
+}
+
+func (m fieldMatcher) String() string {
+	return fmt.Sprintf("field %s matches %v", m.path, m.m)
+}
+
+// resolve returns the value at the field path of m in x, following
+// pointers, or an error naming the first segment that can't be resolved.
+func (m fieldMatcher) resolve(x any) (any, error) {
+	v := reflect.ValueOf(x)
+	for _, name := range strings.Split(m.path, ".") {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return nil, fmt.Errorf("can't get field %s of a nil %v", name, v.Type())
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("can't get field %s of %v, which is not a struct", name, x)
+		}
+		f, ok := v.Type().FieldByName(name)
+		if !ok || !f.IsExported() {
+			return nil, fmt.Errorf("%v has no exported field %s", v.Type(), name)
+		}
+		// A promoted field may be reached through a nil embedded pointer.
+		fv, err := v.FieldByIndexErr(f.Index)
+		if err != nil {
+			return nil, fmt.Errorf("can't get field %s of %v: %w", name, v.Type(), err)
+		}
+		v = fv
+	}
+	return v.Interface(), nil
+}
+
+type mapContainingMatcher struct {
+	keys   []any // sorted by their formatted value
+	values []any // wanted values; Matchers are used as is
+	base   cmp.Options
+}
+
+func (m mapContainingMatcher) Matches(x any) bool {
+	v := reflect.ValueOf(x)
+	if v.Kind() != reflect.Map {
+		return false
+	}
+	for i, key := range m.keys {
+		got, ok := mapIndex(v, key, m.base)
+		if !ok || !m.valueMatches(m.values[i], got.Interface()) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m mapContainingMatcher) Diff(x interface{}, opts ...cmp.Option) string {
+	v := reflect.ValueOf(x)
+	if v.Kind() != reflect.Map {
+		return fmt.Sprintf("got %v (%T), want a map", x, x)
+	}
+	var sb strings.Builder
+	for i, key := range m.keys {
+		got, ok := mapIndex(v, key, m.base)
+		if !ok {
+			fmt.Fprintf(&sb, "\nmissing key %v", getString(key))
+			continue
+		}
+		if want := toMatcher(m.values[i]); !m.valueMatches(m.values[i], got.Interface()) {
+			fmt.Fprintf(&sb, "\n%v: got: %s, want: %v", getString(key), formatGottenArg(want, got.Interface()), want)
+		}
+	}
+	return sb.String()
+}
+
+func (m mapContainingMatcher) String() string {
+	ss := make([]string, len(m.keys))
+	for i, key := range m.keys {
+		ss[i] = getString(key) + ": " + toMatcher(m.values[i]).String()
+	}
+	return "contains entries {" + strings.Join(ss, ", ") + "}"
+}
+
+// valueMatches reports whether got matches wanted, which may be a Matcher.
+func (m mapContainingMatcher) valueMatches(wanted, got any) bool {
+	if wm, ok := wanted.(Matcher); ok {
+		return wm.Matches(got)
+	}
+	return equalWithBase(wanted, got, m.base)
+}
+
+func (m mapContainingMatcher) withBaseCmpOpts(base cmp.Options) Matcher {
+	m.base = base
+	return m
+}
+
+type mapContainingKeysMatcher struct {
+	keys []any
+	base cmp.Options
+}
+
+func (m mapContainingKeysMatcher) Matches(x any) bool {
+	v := reflect.ValueOf(x)
+	if v.Kind() != reflect.Map {
+		return false
+	}
+	for _, key := range m.keys {
+		if _, ok := mapIndex(v, key, m.base); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (m mapContainingKeysMatcher) Diff(x interface{}, opts ...cmp.Option) string {
+	v := reflect.ValueOf(x)
+	if v.Kind() != reflect.Map {
+		return fmt.Sprintf("got %v (%T), want a map", x, x)
+	}
+	var sb strings.Builder
+	for _, key := range m.keys {
+		if _, ok := mapIndex(v, key, m.base); !ok {
+			fmt.Fprintf(&sb, "\nmissing key %v", getString(key))
+		}
+	}
+	return sb.String()
+}
+
+func (m mapContainingKeysMatcher) String() string {
+	ss := make([]string, len(m.keys))
+	for i, key := range m.keys {
+		ss[i] = getString(key)
+	}
+	return "contains keys [" + strings.Join(ss, " ") + "]"
+}
+
+func (m mapContainingKeysMatcher) withBaseCmpOpts(base cmp.Options) Matcher {
+	m.base = base
+	return m
+}
+
+// mapIndex returns the value stored in the map v under key. Without cmp
+// options, key must be assignable to the key type of v and is looked up
+// directly; otherwise the keys of v are compared with key using cmp.Equal.
+func mapIndex(v reflect.Value, key any, opts cmp.Options) (reflect.Value, bool) {
+	if len(opts) > 0 {
+		iter := v.MapRange()
+		for iter.Next() {
+			if cmp.Equal(key, iter.Key().Interface(), opts) {
+				return iter.Value(), true
+			}
+		}
+		return reflect.Value{}, false
+	}
+	kv := reflect.ValueOf(key)
+	if !kv.IsValid() || !kv.Type().AssignableTo(v.Type().Key()) {
+		return reflect.Value{}, false
+	}
+	got := v.MapIndex(kv)
+	return got, got.IsValid()
+}
+
+type typedMatcher[T any] struct {
+	m TypedMatcher[T]
+}
+
+func (m typedMatcher[T]) Matches(x any) bool {
+	v, ok := x.(T)
+	if !ok {
+		// An untyped nil is passed on as the zero value of T, provided that
+		// is nil too.
+		if x != nil || !Nil().Matches(any(v)) {
+			return false
+		}
+	}
+	return m.m.Matches(v)
+}
+
+func (m typedMatcher[T]) String() string {
+	return m.m.String()
+}
+
+type captureMatcher[T any] struct {
+	dest *T
+}
+
+func (m captureMatcher[T]) Matches(x any) bool {
+	v, ok := x.(T)
+	if !ok {
+		// An untyped nil is captured as the zero value of T, provided that
+		// is nil too.
+		if x != nil || !Nil().Matches(any(v)) {
+			return false
+		}
+	}
+	*m.dest = v
+	return true
+}
+
+func (m captureMatcher[T]) String() string {
+	return "captures " + reflect.TypeOf(m.dest).Elem().String()
+}
+
+// toMatcher returns x if it is already a Matcher, and Eq(x) otherwise.
+func toMatcher(x any) Matcher {
+	if m, ok := x.(Matcher); ok {
+		return m
+	}
+	return Eq(x)
+}
+
+// Constructors
+
+// All returns a composite Matcher that returns true if and only all of the
+// matchers return true.
+func All(ms ...Matcher) Matcher { return allMatcher{ms} }
+
+// Any returns a matcher that always matches.
+func Any() Matcher { return anyMatcher{} }
+
+// AnyLog returns a matcher that always matches, like Any, but logs each value
+// it is matched against through t's Logf method, if it has one, as
+// *testing.T does. It helps to find out what a mock was actually called with,
+// e.g. when a different expectation than intended was chosen. Since every
+// expectation of the method is tried in turn, a call may be logged even if it
+// ends up matching another expectation.
+func AnyLog(t TestReporter) Matcher { return anyLogMatcher{t} }
+
+// Cond returns a matcher that matches when the given function returns true
+// after passing it the parameter to the mock function.
+// This is particularly useful in case you want to match over a field of a custom struct, or dynamic logic.
+//
+// Example usage:
+//
+//	Cond(func(x any){return x.(int) == 1}).Matches(1) // returns true
+//	Cond(func(x any){return x.(int) == 2}).Matches(1) // returns false
+func Cond(fn func(x any) bool) Matcher { return condMatcher{fn} }
+
+// AnyOf returns a composite Matcher that returns true if at least one of the
+// matchers returns true.
+//
+// Example usage:
+//
+//	AnyOf(1, 2, 3).Matches(2) // returns true
+//	AnyOf(1, 2, 3).Matches(10) // returns false
+//	AnyOf(Nil(), Len(2)).Matches(nil) // returns true
+//	AnyOf(Nil(), Len(2)).Matches("hi") // returns true
+//	AnyOf(Nil(), Len(2)).Matches("hello") // returns false
+func AnyOf(xs ...any) Matcher {
+	ms := make([]Matcher, 0, len(xs))
+	for _, x := range xs {
+		if m, ok := x.(Matcher); ok {
+			ms = append(ms, m)
+		} else {
+			ms = append(ms, Eq(x))
+		}
+	}
+	return anyOfMatcher{ms}
+}
+
+// Empty returns a matcher that matches if the received value is empty: nil,
+// a string, slice, map or channel of length zero, or the zero value of any
+// other type.
+//
+// Example usage:
+//
+//	Empty().Matches([]int{}) // returns true
+//	Empty().Matches(0) // returns true
+//	Empty().Matches("a") // returns false
+func Empty() Matcher { return emptyMatcher{} }
+
+// Eq returns a matcher that matches on equality.
+//
+// Example usage:
+//
+//	Eq(5).Matches(5) // returns true
+//	Eq(5).Matches(4) // returns false
+func Eq(x any) Matcher { return eqMatcher{x} }
+
+// EqWithOpts returns a matcher that matches if cmp.Equal reports the received
+// value equal to x using opts. When recorded on a Controller created with
+// WithCmpOpts, the Controller's options apply as well, followed by opts, and
+// the same options are used to render the failure diff. Like Eq, it compares
+// unexported fields too, unless opts or the Controller's options ignore them.
+//
+// Example usage:
+//
+//	EqWithOpts(1.0, cmpopts.EquateApprox(0, 0.01)).Matches(1.001) // returns true
+//	EqWithOpts(1.0).Matches(1.001) // returns false
+func EqWithOpts(x any, opts ...cmp.Option) Matcher {
+	opts = append([]cmp.Option{cmp.Exporter(func(reflect.Type) bool { return true })}, opts...)
+	return eqOptsMatcher{x: x, opts: opts}
+}
+
+// EqualMethod returns a matcher that compares the received value to x with
+// the received value's Equal method, if it has one taking a single parameter
+// that x is assignable to and returning a bool, as time.Time does. Otherwise
+// the values are compared like Eq does, or with cmp.Equal when the Controller
+// the matcher is recorded with has cmp options.
+//
+// Example usage:
+//
+//	t := time.Now()
+//	EqualMethod(t).Matches(t.UTC()) // returns true
+//	EqualMethod(1).Matches(1) // returns true
+func EqualMethod(x any) Matcher {
+	return equalMethodMatcher{x: x}
+}
+
+// EqWithFormat returns a matcher that matches on equality like Eq, but uses
+// format to render both the expected value and the received value in
+// failure messages. This keeps failures concise for types whose default
+// formatting is noisy.
+//
+// Example usage:
+//
+//	EqWithFormat(user, func(x any) string { return x.(User).ID })
+func EqWithFormat(x any, format func(any) string) Matcher {
+	return GotFormatterAdapter(
+		GotFormatterFunc(format),
+		WantFormatter(StringerFunc(func() string { return "is equal to " + format(x) }), Eq(x)),
+	)
+}
+
+// Len returns a matcher that matches on length. This matcher returns false if
+// is compared to a type that is not an array, chan, map, slice, or string.
+// m is either the exact length as an int, or a Matcher that is applied to
+// the length.
+//
+// Example usage:
+//
+//	Len(2).Matches([]int{1, 2}) // returns true
+//	Len(Not(0)).Matches("abc") // returns true
+func Len(m any) Matcher {
+	if i, ok := m.(int); ok {
+		return lenMatcher{i}
+	}
+	return lenMatchingMatcher{toMatcher(m)}
+}
+
+// Nil returns a matcher that matches if the received value is nil. This
+// includes typed nils: an interface value holding a nil pointer, map, slice,
+// chan or func matches even though the interface itself is not nil.
+//
+// Example usage:
 //
 //	var x *bytes.Buffer
 //	Nil().Matches(x) // returns true
 //	x = &bytes.Buffer{}
 //	Nil().Matches(x) // returns false
+//	var err error = (*fs.PathError)(nil)
+//	Nil().Matches(err) // returns true
 func Nil() Matcher { return nilMatcher{} }
 
+// NotEmpty returns a matcher that matches if the received value is not
+// empty. It is the inverse of Empty.
+//
+// Example usage:
+//
+//	NotEmpty().Matches(map[string]int{"a": 1}) // returns true
+//	NotEmpty().Matches("") // returns false
+func NotEmpty() Matcher { return notEmptyMatcher{} }
+
+// StringerEq returns a matcher that matches a fmt.Stringer whose String
+// method returns s. Values that don't implement fmt.Stringer don't match.
+//
+// Example usage:
+//
+//	StringerEq("1s").Matches(time.Second) // returns true
+//	StringerEq("1s").Matches("1s") // returns false
+func StringerEq(s string) Matcher { return stringerEqMatcher{s} }
+
+// Truthy returns a matcher that matches "truthy" values, as in dynamically
+// typed languages: true, non-zero numbers, non-nil pointers, non-empty
+// strings, slices, maps and channels, and in general any value that Empty
+// doesn't match.
+//
+// Example usage:
+//
+//	Truthy().Matches(1) // returns true
+//	Truthy().Matches([]int{}) // returns false
+func Truthy() Matcher { return truthyMatcher{truthy: true} }
+
+// Falsy returns a matcher that matches the values Truthy doesn't: nil,
+// false, zero numbers, empty strings and collections, and other zero values.
+//
+// Example usage:
+//
+//	Falsy().Matches("") // returns true
+//	Falsy().Matches(new(int)) // returns false
+func Falsy() Matcher { return truthyMatcher{truthy: false} }
+
+// NotNil returns a matcher that matches if the received value is not nil.
+// It is the inverse of Nil, so a typed nil such as a nil pointer passed as
+// an interface does not match.
+//
+// Example usage:
+//
+//	NotNil().Matches(&bytes.Buffer{}) // returns true
+//	NotNil().Matches((*bytes.Buffer)(nil)) // returns false
+//	NotNil().Matches(nil) // returns false
+func NotNil() Matcher { return notNilMatcher{} }
+
+// Same returns a matcher that matches x only if it is identical to ptr: of
+// the same type and referring to the same memory. Unlike Eq, two equal but
+// distinct values don't match. ptr must be a pointer, channel, func or map;
+// Same panics otherwise.
+//
+// Example usage:
+//
+//	a, b := new(int), new(int)
+//	Same(a).Matches(a) // returns true
+//	Same(a).Matches(b) // returns false
+func Same(ptr any) Matcher {
+	switch reflect.ValueOf(ptr).Kind() {
+	case reflect.Ptr, reflect.Chan, reflect.Func, reflect.Map:
+		return sameMatcher{ptr}
+	default:
+		panic(fmt.Sprintf("gomock.Same: %T is not a pointer, channel, func or map", ptr))
+	}
+}
+
+// NotCancelledContext returns a matcher that matches a context.Context that
+// is neither cancelled nor past its deadline when the call is made.
+//
+// Example usage:
+//
+//	ctx, cancel := context.WithCancel(context.Background())
+//	NotCancelledContext().Matches(ctx) // returns true
+//	cancel()
+//	NotCancelledContext().Matches(ctx) // returns false
+func NotCancelledContext() Matcher { return notCancelledContextMatcher{} }
+
+// ContextWithDeadlineWithin returns a matcher that matches a context.Context
+// whose deadline is at most d after the time of the call, which checks that
+// callers set a sane timeout. Contexts without a deadline never match.
+//
+// Example usage:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//	ContextWithDeadlineWithin(time.Minute).Matches(ctx) // returns true
+//	ContextWithDeadlineWithin(time.Minute).Matches(context.Background()) // returns false
+func ContextWithDeadlineWithin(d time.Duration) Matcher {
+	return deadlineWithinMatcher{d}
+}
+
+// TimeEq returns a matcher that matches a time.Time that is at most tolerance
+// before or after t. Unlike Eq, it compares instants, so monotonic clock
+// readings and locations are ignored.
+//
+// Example usage:
+//
+//	now := time.Now()
+//	TimeEq(now, time.Second).Matches(now.Add(time.Millisecond)) // returns true
+//	TimeEq(now, time.Second).Matches(now.UTC()) // returns true
+//	TimeEq(now, time.Second).Matches(now.Add(time.Minute)) // returns false
+func TimeEq(t time.Time, tolerance time.Duration) Matcher {
+	if tolerance < 0 {
+		tolerance = -tolerance
+	}
+	return timeEqMatcher{t: t.Round(0), tolerance: tolerance}
+}
+
+// DurationApprox returns a matcher that matches a time.Duration that is at
+// most tolerance shorter or longer than d, e.g. a computed backoff that may
+// include some jitter.
+//
+// Example usage:
+//
+//	DurationApprox(time.Second, 100*time.Millisecond).Matches(950*time.Millisecond) // returns true
+//	DurationApprox(time.Second, 100*time.Millisecond).Matches(2*time.Second) // returns false
+//	DurationApprox(time.Second, 100*time.Millisecond).Matches(int64(time.Second)) // returns false
+func DurationApprox(d, tolerance time.Duration) Matcher {
+	if tolerance < 0 {
+		tolerance = -tolerance
+	}
+	return durationApproxMatcher{d: d, tolerance: tolerance}
+}
+
+// EqIgnoring returns a matcher that matches if the received value is equal to
+// x, a struct or pointer to struct, except for the named fields, which are
+// ignored. Fields of nested structs are named by their dot-separated path
+// from x, e.g. "Meta.UpdatedAt". This saves ignoring the fields for every
+// argument with WithCmpOpts; the Controller's options still apply. Like Eq,
+// it compares unexported fields too. EqIgnoring panics if x is not a struct
+// or pointer to struct, or if a field doesn't exist.
+//
+// Example usage:
+//
+//	EqIgnoring(Event{ID: 1, At: t1}, "At").Matches(Event{ID: 1, At: t2}) // returns true
+//	EqIgnoring(Event{ID: 1, At: t1}, "At").Matches(Event{ID: 2, At: t1}) // returns false
+func EqIgnoring(x any, fields ...string) Matcher {
+	t := reflect.TypeOf(x)
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("gomock.EqIgnoring: %T is not a struct or pointer to struct", x))
+	}
+	opts := cmp.Options{
+		cmpopts.IgnoreFields(reflect.Zero(t).Interface(), fields...),
+		cmp.Exporter(func(reflect.Type) bool { return true }),
+	}
+	return eqOptsMatcher{x: x, opts: opts, ignored: fields}
+}
+
 // Not reverses the results of its given child matcher.
 //
 // Example usage:
@@ -493,6 +1664,21 @@ func Not(x any) Matcher {
 	return notMatcher{Eq(x)}
 }
 
+// When returns a matcher that calls cond each time it is matched against a
+// value, and fails if cond returns false. Otherwise it delegates to m, which
+// is wrapped with Eq if it isn't a Matcher. It allows enabling an expectation
+// depending on the state of the test, e.g. a feature flag.
+//
+// Example usage:
+//
+//	enabled := false
+//	When(func() bool { return enabled }, 5).Matches(5) // returns false
+//	enabled = true
+//	When(func() bool { return enabled }, 5).Matches(5) // returns true
+func When(cond func() bool, m any) Matcher {
+	return whenMatcher{cond: cond, m: toMatcher(m)}
+}
+
 // Regex checks whether parameter matches the associated regex.
 //
 // Example usage:
@@ -505,6 +1691,19 @@ func Regex(regexStr string) Matcher {
 	return regexMatcher{regex: regexp.MustCompile(regexStr)}
 }
 
+// EqualFold returns a matcher that matches a string or []byte equal to s
+// under Unicode case-folding, as reported by strings.EqualFold. It is useful
+// for values such as HTTP header names.
+//
+// Example usage:
+//
+//	EqualFold("Content-Type").Matches("content-type") // returns true
+//	EqualFold("Content-Type").Matches([]byte("CONTENT-TYPE")) // returns true
+//	EqualFold("Content-Type").Matches("Content-Length") // returns false
+func EqualFold(s string) Matcher {
+	return equalFoldMatcher{s}
+}
+
 // AssignableToTypeOf is a Matcher that matches if the parameter to the mock
 // function is assignable to the type of the parameter to this function.
 //
@@ -518,17 +1717,351 @@ func Regex(regexStr string) Matcher {
 //	AssignableToTypeOf(ctx).Matches(context.Background()) // returns true
 func AssignableToTypeOf(x any) Matcher {
 	if xt, ok := x.(reflect.Type); ok {
-		return assignableToTypeOfMatcher{xt}
+		return AssignableToType(xt)
+	}
+	return AssignableToType(reflect.TypeOf(x))
+}
+
+// AssignableToType is a Matcher that matches if the parameter to the mock
+// function is assignable to t. Unlike AssignableToTypeOf, it takes the type
+// itself, which makes the intent explicit when t is an interface type.
+// An untyped nil never matches.
+//
+// Example usage:
+//
+//	reader := reflect.TypeOf((*io.Reader)(nil)).Elem()
+//	AssignableToType(reader).Matches(&bytes.Buffer{}) // returns true
+//	AssignableToType(reader).Matches("hello") // returns false
+func AssignableToType(t reflect.Type) Matcher {
+	return assignableToTypeOfMatcher{t}
+}
+
+// AnyOfType returns a matcher that matches any value whose dynamic type is
+// exactly t. If t is an interface type, it matches any value implementing t.
+// Use AssignableToType to also match values of other assignable types.
+// An untyped nil never matches.
+//
+// Example usage:
+//
+//	type IDs []int
+//	AnyOfType(reflect.TypeOf([]int(nil))).Matches([]int{1}) // returns true
+//	AnyOfType(reflect.TypeOf([]int(nil))).Matches(IDs{1}) // returns false
+//	AssignableToType(reflect.TypeOf([]int(nil))).Matches(IDs{1}) // returns true
+func AnyOfType(t reflect.Type) Matcher {
+	return anyOfTypeMatcher{t}
+}
+
+// AnyType is like AnyOfType, but takes the type as a type parameter.
+//
+// Example usage:
+//
+//	AnyType[string]().Matches("hello") // returns true
+//	AnyType[error]().Matches(io.EOF) // returns true
+func AnyType[T any]() Matcher {
+	return AnyOfType(reflect.TypeOf((*T)(nil)).Elem())
+}
+
+// AnyChan returns a matcher that matches any channel with element type elem
+// and direction dir, including channels of named types. It panics if dir is
+// not one of reflect.RecvDir, reflect.SendDir and reflect.BothDir.
+//
+// Example usage:
+//
+//	AnyChan(reflect.TypeOf(0), reflect.BothDir).Matches(make(chan int)) // returns true
+//	AnyChan(reflect.TypeOf(0), reflect.RecvDir).Matches(make(chan int)) // returns false
+func AnyChan(elem reflect.Type, dir reflect.ChanDir) Matcher {
+	switch dir {
+	case reflect.RecvDir, reflect.SendDir, reflect.BothDir:
+		return anyChanMatcher{elem, dir}
+	default:
+		panic(fmt.Sprintf("gomock.AnyChan: invalid channel direction %d", dir))
 	}
-	return assignableToTypeOfMatcher{reflect.TypeOf(x)}
 }
 
 // InAnyOrder is a Matcher that returns true for collections of the same elements ignoring the order.
+// Duplicate elements must occur the same number of times in both collections.
+// If the Controller has cmp options, elements are compared using them.
+// When given as the last argument of an expected call of a variadic method,
+// it matches the variadic arguments in any order.
 //
 // Example usage:
 //
 //	InAnyOrder([]int{1, 2, 3}).Matches([]int{1, 3, 2}) // returns true
 //	InAnyOrder([]int{1, 2, 3}).Matches([]int{1, 2}) // returns false
+//	m.EXPECT().Tag(id, InAnyOrder([]string{"a", "b"})) // matches m.Tag(id, "b", "a")
 func InAnyOrder(x any) Matcher {
-	return inAnyOrderMatcher{x}
+	return inAnyOrderMatcher{x: x}
+}
+
+// Sorted returns a matcher that matches a slice or array whose elements are
+// sorted according to less, which reports whether its first argument sorts
+// before its second. Equal elements may appear in any order. Values that are
+// not slices or arrays don't match.
+//
+// Example usage:
+//
+//	intLess := func(a, b any) bool { return a.(int) < b.(int) }
+//	Sorted(intLess).Matches([]int{1, 2, 2, 3}) // returns true
+//	Sorted(intLess).Matches([]int{2, 1}) // returns false
+//	Sorted(intLess).Matches(1) // returns false
+func Sorted(less func(a, b any) bool) Matcher {
+	return sortedMatcher{less}
+}
+
+// SameFunc returns a matcher that matches a function of the same type as fn
+// with the same code pointer, as returned by reflect.Value.Pointer. Go doesn't
+// allow comparing functions, and the code pointer is all that can be
+// compared: closures created by the same function literal match each other
+// whatever variables they capture, and so do method values of the same method
+// on different receivers. SameFunc panics if fn is not a non-nil function.
+//
+// Example usage:
+//
+//	SameFunc(strings.ToUpper).Matches(strings.ToUpper) // returns true
+//	SameFunc(strings.ToUpper).Matches(strings.ToLower) // returns false
+func SameFunc(fn any) Matcher {
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func || v.IsNil() {
+		panic(fmt.Sprintf("gomock.SameFunc: %T is not a non-nil function", fn))
+	}
+	return sameFuncMatcher{v}
+}
+
+// JSONEq returns a matcher that matches if the received value is a string,
+// []byte or json.RawMessage holding JSON semantically equal to expected.
+// Key order and whitespace are ignored. The matcher never matches if either
+// side is not valid JSON.
+//
+// Example usage:
+//
+//	JSONEq(`{"a": 1, "b": 2}`).Matches(`{"b":2,"a":1}`) // returns true
+//	JSONEq(`{"a": 1}`).Matches([]byte(`{"a": 2}`)) // returns false
+//	JSONEq(`{"a": 1}`).Matches("not json") // returns false
+func JSONEq(expected string) Matcher {
+	want, err := unmarshalJSONArg(expected)
+	return jsonEqMatcher{want: want, wantErr: err, raw: expected}
+}
+
+// ProtoEq returns a matcher that matches a protobuf message equal to msg
+// according to equal, which should normally be proto.Equal from
+// google.golang.org/protobuf/proto. Unlike Eq, it ignores the internal state
+// of messages, such as cached sizes. Taking equal as an argument keeps this
+// package free of a dependency on protobuf. msg must be of the type accepted
+// by equal, or ProtoEq panics. Failure messages show messages in text form,
+// as returned by their String method.
+//
+// Example usage:
+//
+//	ProtoEq(&pb.User{Name: "Fido"}, proto.Equal)
+func ProtoEq[M any](msg any, equal func(x, y M) bool) Matcher {
+	m, ok := msg.(M)
+	if !ok {
+		panic(fmt.Sprintf("gomock.ProtoEq: %T is not a %v", msg, reflect.TypeOf((*M)(nil)).Elem()))
+	}
+	return protoEqMatcher[M]{msg: m, equal: equal}
+}
+
+// ErrorIs returns a matcher that matches if the received value is an error
+// for which errors.Is(x, target) reports true. Values that are not errors
+// never match.
+//
+// Example usage:
+//
+//	ErrorIs(io.EOF).Matches(fmt.Errorf("read: %w", io.EOF)) // returns true
+//	ErrorIs(io.EOF).Matches(io.ErrUnexpectedEOF) // returns false
+//	ErrorIs(io.EOF).Matches("EOF") // returns false
+func ErrorIs(target error) Matcher {
+	return errorIsMatcher{target}
+}
+
+// ErrorContains returns a matcher that matches a non-nil error whose Error
+// method returns a string containing substr. It is useful when there is no
+// sentinel error to match with ErrorIs. Nil errors and values that are not
+// errors never match.
+//
+// Example usage:
+//
+//	ErrorContains("timeout").Matches(fmt.Errorf("dial: %w", errors.New("i/o timeout"))) // returns true
+//	ErrorContains("timeout").Matches(io.EOF) // returns false
+//	ErrorContains("timeout").Matches("timeout") // returns false
+func ErrorContains(substr string) Matcher {
+	return errorContainsMatcher{substr}
+}
+
+// ErrorAs returns a matcher that matches if the received value is an error
+// for which errors.As(x, target) reports true. target must be a non-nil
+// pointer to a type implementing error, or to an interface type; ErrorAs
+// panics otherwise.
+//
+// On a successful match the extracted error is stored in target, so that it
+// can be inspected later, e.g. from a Do callback. Since target is mutated,
+// a single ErrorAs matcher should not be shared between expectations.
+//
+// Example usage:
+//
+//	var pathErr *fs.PathError
+//	ErrorAs(&pathErr).Matches(fmt.Errorf("open: %w", &fs.PathError{})) // returns true, sets pathErr
+//	ErrorAs(&pathErr).Matches(io.EOF) // returns false, leaves pathErr untouched
+func ErrorAs(target any) Matcher {
+	if target == nil {
+		panic("gomock: ErrorAs target cannot be nil")
+	}
+	val := reflect.ValueOf(target)
+	typ := val.Type()
+	if typ.Kind() != reflect.Ptr || val.IsNil() {
+		panic("gomock: ErrorAs target must be a non-nil pointer")
+	}
+	errorType := reflect.TypeOf((*error)(nil)).Elem()
+	if targetType := typ.Elem(); targetType.Kind() != reflect.Interface && !targetType.Implements(errorType) {
+		panic("gomock: ErrorAs *target must be interface or implement error")
+	}
+	return errorAsMatcher{target}
+}
+
+// Implements returns a matcher that matches if the dynamic type of the
+// received value implements the interface pointed to by ifacePtr, which must
+// be a nil pointer to an interface type such as (*io.Closer)(nil). Implements
+// panics otherwise. Method sets follow the usual Go rules, so a value whose
+// methods have pointer receivers only implements the interface when passed by
+// pointer. An untyped nil never matches.
+//
+// Example usage:
+//
+//	Implements((*io.Closer)(nil)).Matches(os.Stdin) // returns true
+//	Implements((*io.Closer)(nil)).Matches(&bytes.Buffer{}) // returns false
+func Implements(ifacePtr any) Matcher {
+	t := reflect.TypeOf(ifacePtr)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Interface {
+		panic(fmt.Sprintf("gomock: Implements expects a pointer to an interface, got %T", ifacePtr))
+	}
+	return implementsMatcher{t.Elem()}
+}
+
+// Pointee returns a matcher that dereferences the received pointer and
+// matches the pointed-to value against m. If m is not a Matcher it is
+// wrapped with Eq. Nil pointers and non-pointer values never match.
+//
+// Example usage:
+//
+//	five := 5
+//	Pointee(5).Matches(&five) // returns true
+//	Pointee(Eq(5)).Matches(5) // returns false
+//	Pointee(Any()).Matches((*int)(nil)) // returns false
+func Pointee(m any) Matcher {
+	return pointeeMatcher{toMatcher(m)}
+}
+
+// FieldsEq returns a matcher that matches a struct, or pointer to struct,
+// whose named exported fields match the given values. Values that are not
+// Matchers are wrapped with Eq. Fields not listed are ignored.
+//
+// FieldsEq panics if a field name is not exported. When used to record an
+// expectation against a struct-typed parameter, it also panics if the struct
+// has no field with one of the given names.
+//
+// Example usage:
+//
+//	FieldsEq(map[string]any{"Name": "Fido"}).Matches(Dog{Breed: "pug", Name: "Fido"}) // returns true
+//	FieldsEq(map[string]any{"Name": Regex("^F")}).Matches(&Dog{Name: "Rex"}) // returns false
+func FieldsEq(fields map[string]any) Matcher {
+	m := fieldsEqMatcher{
+		names:  make([]string, 0, len(fields)),
+		fields: make(map[string]Matcher, len(fields)),
+	}
+	for name, x := range fields {
+		if !token.IsExported(name) {
+			panic(fmt.Sprintf("gomock: FieldsEq: field %q is not exported", name))
+		}
+		m.names = append(m.names, name)
+		m.fields[name] = toMatcher(x)
+	}
+	sort.Strings(m.names)
+	return m
+}
+
+// Field returns a matcher that matches a struct, or pointer to struct, whose
+// field at path matches m. path is a dotted sequence of exported field names,
+// like "User.Address.City", and pointers are followed along the way. If m is
+// not a Matcher, it is wrapped with Eq. Values that don't have a field at
+// path don't match.
+//
+// Example usage:
+//
+//	Field("Owner.Name", "Fido").Matches(Dog{Owner: &Person{Name: "Fido"}}) // returns true
+//	Field("Owner.Name", "Fido").Matches(Dog{}) // returns false
+func Field(path string, m any) Matcher {
+	return fieldMatcher{path: path, m: toMatcher(m)}
+}
+
+// MapContaining returns a matcher that matches a map containing all entries
+// of subset, which must itself be a map. Values in subset that are not
+// Matchers are compared like Eq does, or with cmp.Equal when the Controller
+// has cmp options; the same goes for the keys. Additional entries are
+// ignored.
+//
+// Example usage:
+//
+//	MapContaining(map[string]any{"a": 1}).Matches(map[string]int{"a": 1, "b": 2}) // returns true
+//	MapContaining(map[string]any{"a": Not(1)}).Matches(map[string]int{"a": 1}) // returns false
+func MapContaining(subset any) Matcher {
+	v := reflect.ValueOf(subset)
+	if v.Kind() != reflect.Map {
+		panic(fmt.Sprintf("gomock: MapContaining: %T is not a map", subset))
+	}
+	m := mapContainingMatcher{
+		keys:   make([]any, 0, v.Len()),
+		values: make([]any, 0, v.Len()),
+	}
+	keys := v.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return getString(keys[i].Interface()) < getString(keys[j].Interface())
+	})
+	for _, key := range keys {
+		m.keys = append(m.keys, key.Interface())
+		m.values = append(m.values, v.MapIndex(key).Interface())
+	}
+	return m
+}
+
+// MapContainingKeys returns a matcher that matches a map containing all of
+// the given keys, whatever their values. Keys are compared as by
+// MapContaining.
+//
+// Example usage:
+//
+//	MapContainingKeys("a", "b").Matches(map[string]int{"a": 1, "b": 2, "c": 3}) // returns true
+//	MapContainingKeys("a", "b").Matches(map[string]int{"a": 1}) // returns false
+func MapContainingKeys(keys ...any) Matcher {
+	return mapContainingKeysMatcher{keys: keys}
+}
+
+// Capture returns a matcher that matches any value of type T and stores it
+// in *dest, so that it can be inspected after the call. Values of any other
+// type don't match. Note that the value is stored whenever the matcher is
+// evaluated, even if other arguments of the same call then don't match.
+//
+// Example usage:
+//
+//	var got Request
+//	m.EXPECT().Send(gomock.Capture(&got))
+//	// ... exercise the code under test ...
+//	if got.ID != "42" { t.Errorf(...) }
+func Capture[T any](dest *T) Matcher {
+	return captureMatcher[T]{dest}
+}
+
+// Typed adapts a TypedMatcher to a Matcher. The received value is asserted to
+// be of type T before being passed to m; values of any other type never
+// match.
+//
+// Example usage:
+//
+//	type evenMatcher struct{}
+//	func (evenMatcher) Matches(x int) bool { return x%2 == 0 }
+//	func (evenMatcher) String() string { return "is even" }
+//
+//	Typed[int](evenMatcher{}).Matches(2) // returns true
+//	Typed[int](evenMatcher{}).Matches("2") // returns false
+func Typed[T any](m TypedMatcher[T]) Matcher {
+	return typedMatcher[T]{m}
 }
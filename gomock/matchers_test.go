@@ -17,11 +17,18 @@ package gomock_test
 //go:generate mockgen -destination internal/mock_gomock/mock_matcher.go go.uber.org/mock/gomock Matcher
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"go.uber.org/mock/gomock"
 	"go.uber.org/mock/gomock/internal/mock_gomock"
 )
@@ -44,8 +51,18 @@ func TestMatchers(t *testing.T) {
 			[]e{"s", "", 0, 4, 10}},
 		{"test All", gomock.Eq(4), []e{4}, []e{3, "blah", nil, int64(4)}},
 		{"test Nil", gomock.Nil(),
-			[]e{nil, (error)(nil), (chan bool)(nil), (*int)(nil)},
+			[]e{nil, (error)(nil), (chan bool)(nil), (*int)(nil), error((*codeError)(nil)), fmt.Stringer((*bytes.Buffer)(nil)), (func())(nil), map[int]int(nil)},
 			[]e{"", 0, make(chan bool), errors.New("err"), new(int)}},
+		{"test NotNil", gomock.NotNil(),
+			[]e{"", 0, make(chan bool), errors.New("err"), new(int), &Dog{}, context.Background()},
+			[]e{nil, (error)(nil), (*int)(nil), error((*codeError)(nil)), []int(nil)}},
+		{"test Empty", gomock.Empty(),
+			[]e{nil, "", []int{}, []int(nil), map[string]int{}, make(chan int), 0, Dog{}, (*int)(nil), [2]int{}},
+			[]e{"a", []int{1}, map[string]int{"a": 1}, 1, Dog{Name: "Fido"}, new(int), [2]int{1, 0}}},
+		{"test NotEmpty", gomock.NotEmpty(),
+			[]e{"a", []int{1}, map[string]int{"a": 1}, 1, Dog{Name: "Fido"}, new(int)},
+			[]e{nil, "", []int{}, map[string]int{}, 0, Dog{}}},
+		{"test EqWithOpts", gomock.EqWithOpts(1.0, cmpopts.EquateApprox(0, 0.01)), []e{1.0, 1.005}, []e{1.1, 1, "1"}},
 		{"test Not", gomock.Not(gomock.Eq(4)), []e{3, "blah", nil, int64(4)}, []e{4}},
 		{"test Regex", gomock.Regex("[0-9]{2}:[0-9]{2}"), []e{"23:02", "[23:02]: Hello world", []byte("23:02")}, []e{4, "23-02", "hello world", true, []byte("23-02")}},
 		{"test All", gomock.All(gomock.Any(), gomock.Eq(4)), []e{4}, []e{3, "blah", nil, int64(4)}},
@@ -58,6 +75,22 @@ func TestMatchers(t *testing.T) {
 			[]e{[]string{"a"}, A{"b"}},
 		},
 		{"test Cond", gomock.Cond(func(x any) bool { return x.(B).Name == "Dam" }), []e{B{Name: "Dam"}}, []e{B{Name: "Dave"}}},
+		{"test JSONEq", gomock.JSONEq(`{"a": 1, "b": [true, null]}`),
+			[]e{`{"b":[true,null],"a":1}`, []byte(` {"a":1.0,"b":[true,null]} `), json.RawMessage(`{"a":1,"b":[true,null]}`)},
+			[]e{`{"a":1}`, `{"a":1,"b":[true,null]} {}`, "not json", 1, nil, map[string]any{"a": 1}}},
+		{"test JSONEq invalid", gomock.JSONEq(`{"a":`), nil, []e{`{"a":`, `{}`}},
+		{"test ErrorIs", gomock.ErrorIs(context.Canceled),
+			[]e{context.Canceled, fmt.Errorf("wrapped: %w", context.Canceled)},
+			[]e{nil, context.DeadlineExceeded, errors.New("context canceled"), "context canceled", 1}},
+		{"test Pointee", gomock.Pointee(gomock.Eq(5)),
+			[]e{intPtr(5)},
+			[]e{5, intPtr(4), (*int)(nil), nil, "5"}},
+		{"test Pointee coerces value", gomock.Pointee(Dog{Name: "Fido"}),
+			[]e{&Dog{Name: "Fido"}},
+			[]e{Dog{Name: "Fido"}, &Dog{Name: "Rex"}}},
+		{"test FieldsEq", gomock.FieldsEq(map[string]any{"Name": "Fido", "Breed": gomock.Regex("^p")}),
+			[]e{Dog{Breed: "pug", Name: "Fido"}, &Dog{Breed: "poodle", Name: "Fido"}},
+			[]e{Dog{Breed: "lab", Name: "Fido"}, Dog{Breed: "pug", Name: "Rex"}, (*Dog)(nil), nil, "Fido", B{Name: "Fido"}}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -227,6 +260,18 @@ func TestInAnyOrder(t *testing.T) {
 			given:     []int{1, 3, 2},
 			wantMatch: true,
 		},
+		{
+			name:      "match for slices with duplicate elements of different order",
+			wanted:    []int{1, 1, 2},
+			given:     []int{1, 2, 1},
+			wantMatch: true,
+		},
+		{
+			name:      "not match for slices with different counts of duplicate elements",
+			wanted:    []int{1, 1, 2},
+			given:     []int{1, 2, 2},
+			wantMatch: false,
+		},
 		{
 			name:      "not match for slices with different elements",
 			wanted:    []int{1, 2, 3},
@@ -356,3 +401,1190 @@ func TestInAnyOrder(t *testing.T) {
 		})
 	}
 }
+
+func TestWithGotFormat(t *testing.T) {
+	m := gomock.WithGotFormat(gomock.Eq(15), func(x any) string { return fmt.Sprintf("<%v>", x) })
+	if !m.Matches(15) {
+		t.Errorf("expected 15 to match %v", m)
+	}
+	if m.Matches(16) {
+		t.Errorf("expected 16 not to match %v", m)
+	}
+	if got, want := m.String(), "is equal to 15 (int)"; got != want {
+		t.Errorf("got string = %q, want string = %q", got, want)
+	}
+	gf, ok := m.(gomock.GotFormatter)
+	if !ok {
+		t.Fatalf("%T does not implement GotFormatter", m)
+	}
+	if got, want := gf.Got(16), "<16>"; got != want {
+		t.Errorf("got Got = %q, want %q", got, want)
+	}
+}
+
+func TestErrorIsMatcher_String(t *testing.T) {
+	want := `is an error matching "context canceled"`
+	if got := gomock.ErrorIs(context.Canceled).String(); got != want {
+		t.Errorf("got string = %q, want string = %q", got, want)
+	}
+}
+
+func TestErrorContainsMatcher(t *testing.T) {
+	matcher := gomock.ErrorContains("timeout")
+
+	tests := []struct {
+		name string
+		x    any
+		want bool
+	}{
+		{"wrapped match", fmt.Errorf("dial tcp: %w", errors.New("i/o timeout")), true},
+		{"no match", errors.New("connection refused"), false},
+		{"string", "timeout", false},
+		{"nil", nil, false},
+		{"typed nil", (*codeError)(nil), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matcher.Matches(tt.x); got != tt.want {
+				t.Errorf("got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+
+	if got, want := matcher.String(), `is an error containing "timeout"`; got != want {
+		t.Errorf("got string = %q, want string = %q", got, want)
+	}
+}
+
+type codeError struct {
+	code int
+}
+
+func (e *codeError) Error() string {
+	return fmt.Sprintf("code %d", e.code)
+}
+
+func TestErrorAsMatcher(t *testing.T) {
+	var target *codeError
+	matcher := gomock.ErrorAs(&target)
+
+	if matcher.Matches(errors.New("code 1")) {
+		t.Errorf("ErrorAs(&target) should not match a plain error")
+	}
+	if matcher.Matches("code 1") {
+		t.Errorf("ErrorAs(&target) should not match a non-error")
+	}
+	if matcher.Matches(nil) {
+		t.Errorf("ErrorAs(&target) should not match nil")
+	}
+	if target != nil {
+		t.Fatalf("target was set without a successful match: %v", target)
+	}
+
+	want := &codeError{code: 42}
+	if !matcher.Matches(fmt.Errorf("wrapped: %w", want)) {
+		t.Fatalf("ErrorAs(&target) should match a wrapped *codeError")
+	}
+	if target != want {
+		t.Errorf("target = %v, want %v", target, want)
+	}
+
+	if got, wantStr := matcher.String(), "is an error assignable to *gomock_test.codeError"; got != wantStr {
+		t.Errorf("got string = %q, want string = %q", got, wantStr)
+	}
+}
+
+func TestErrorAsMatcher_InvalidTarget(t *testing.T) {
+	tests := []struct {
+		name   string
+		target any
+	}{
+		{"nil", nil},
+		{"non-pointer", codeError{}},
+		{"nil pointer", (**codeError)(nil)},
+		{"pointer to non-error", new(int)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("ErrorAs(%v) did not panic", tt.target)
+				}
+			}()
+			gomock.ErrorAs(tt.target)
+		})
+	}
+}
+
+// message stands in for proto.Message, and userMessage for a generated
+// message type with internal state.
+type message interface {
+	ProtoMessage()
+}
+
+type userMessage struct {
+	Name      string
+	sizeCache int32
+}
+
+func (*userMessage) ProtoMessage() {}
+
+func (m *userMessage) String() string {
+	return fmt.Sprintf("name:%q", m.Name)
+}
+
+// messageEqual stands in for proto.Equal.
+func messageEqual(x, y message) bool {
+	ux, ok1 := x.(*userMessage)
+	uy, ok2 := y.(*userMessage)
+	return ok1 && ok2 && ux.Name == uy.Name
+}
+
+func TestProtoEqMatcher(t *testing.T) {
+	want := &userMessage{Name: "Fido"}
+	got := &userMessage{Name: "Fido", sizeCache: 12}
+	matcher := gomock.ProtoEq(want, messageEqual)
+
+	if !matcher.Matches(got) {
+		t.Errorf("expected %v to match %v", got, matcher)
+	}
+	if gomock.Eq(want).Matches(got) {
+		t.Errorf("expected Eq not to match messages with different internal state")
+	}
+	if matcher.Matches(&userMessage{Name: "Rex"}) {
+		t.Errorf("expected a message with another name not to match %v", matcher)
+	}
+	if matcher.Matches("Fido") {
+		t.Errorf("expected a string not to match %v", matcher)
+	}
+
+	wantStr := `is equal to {name:"Fido"} (*gomock_test.userMessage)`
+	if got := matcher.String(); got != wantStr {
+		t.Errorf("got string = %q, want string = %q", got, wantStr)
+	}
+	wantGot := `{name:"Rex"} (*gomock_test.userMessage)`
+	if got := matcher.(gomock.GotFormatter).Got(&userMessage{Name: "Rex"}); got != wantGot {
+		t.Errorf("got Got = %q, want %q", got, wantGot)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("ProtoEq with a value of the wrong type did not panic")
+		}
+	}()
+	gomock.ProtoEq("Fido", messageEqual)
+}
+
+func TestJSONEqMatcher_String(t *testing.T) {
+	tests := []struct {
+		expected string
+		want     string
+	}{
+		{`{ "b": 2,  "a": [1, "x"] }`, `is JSON equal to {"a":[1,"x"],"b":2}`},
+		{`"str"`, `is JSON equal to "str"`},
+	}
+	for _, tt := range tests {
+		if got := gomock.JSONEq(tt.expected).String(); got != tt.want {
+			t.Errorf("JSONEq(%q).String() = %q, want %q", tt.expected, got, tt.want)
+		}
+	}
+
+	if got := gomock.JSONEq(`{`).String(); !strings.HasPrefix(got, "is JSON equal to { (invalid JSON: ") {
+		t.Errorf("JSONEq with invalid JSON: unexpected String() %q", got)
+	}
+}
+
+func TestAssignableToTypeMatcher(t *testing.T) {
+	readerType := reflect.TypeOf((*io.Reader)(nil)).Elem()
+	matcher := gomock.AssignableToType(readerType)
+
+	if !matcher.Matches(&bytes.Buffer{}) {
+		t.Errorf(`AssignableToType(io.Reader) should match &bytes.Buffer{}`)
+	}
+	if !matcher.Matches(strings.NewReader("abc")) {
+		t.Errorf(`AssignableToType(io.Reader) should match *strings.Reader`)
+	}
+	if matcher.Matches(bytes.Buffer{}) {
+		t.Errorf(`AssignableToType(io.Reader) should not match bytes.Buffer{}`)
+	}
+	if matcher.Matches("abc") {
+		t.Errorf(`AssignableToType(io.Reader) should not match "abc"`)
+	}
+	if matcher.Matches(nil) {
+		t.Errorf(`AssignableToType(io.Reader) should not match nil`)
+	}
+	if got, want := matcher.String(), "is assignable to io.Reader"; got != want {
+		t.Errorf("got string = %q, want string = %q", got, want)
+	}
+	if got, want := gomock.AssignableToTypeOf(&Dog{}).String(), "is assignable to *gomock_test.Dog"; got != want {
+		t.Errorf("got string = %q, want string = %q", got, want)
+	}
+}
+
+func TestLenMatcher(t *testing.T) {
+	between := func(lo, hi int) gomock.Matcher {
+		return gomock.Cond(func(x any) bool { n := x.(int); return lo <= n && n <= hi })
+	}
+
+	for _, tt := range []struct {
+		name    string
+		matcher gomock.Matcher
+		x       any
+		want    bool
+	}{
+		{"Eq match", gomock.Len(gomock.Eq(2)), []int{1, 2}, true},
+		{"Eq mismatch", gomock.Len(gomock.Eq(2)), "abc", false},
+		{"range lower bound", gomock.Len(between(1, 5)), []string{"a"}, true},
+		{"range upper bound", gomock.Len(between(1, 5)), map[int]int{1: 1, 2: 2, 3: 3, 4: 4, 5: 5}, true},
+		{"below range", gomock.Len(between(1, 5)), []int{}, false},
+		{"above range", gomock.Len(between(1, 5)), "abcdef", false},
+		{"composed", gomock.Len(gomock.All(gomock.Not(0), gomock.Not(3))), [2]int{}, true},
+		{"not a collection", gomock.Len(gomock.Any()), 42, false},
+		{"nil", gomock.Len(gomock.Any()), nil, false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.matcher.Matches(tt.x); got != tt.want {
+				t.Errorf("%v.Matches(%v) = %v, want %v", tt.matcher, tt.x, got, tt.want)
+			}
+		})
+	}
+
+	if got, want := gomock.Len(gomock.Eq(2)).String(), "has length matching is equal to 2 (int)"; got != want {
+		t.Errorf("got string = %q, want string = %q", got, want)
+	}
+	if got, want := gomock.Len(2).String(), "has length 2"; got != want {
+		t.Errorf("got string = %q, want string = %q", got, want)
+	}
+}
+
+func TestCaptureMatcher(t *testing.T) {
+	var s string
+	m := gomock.Capture(&s)
+	if !m.Matches("abc") || s != "abc" {
+		t.Errorf(`Capture(&s).Matches("abc") should match and capture "abc", captured %q`, s)
+	}
+	if m.Matches(1) || s != "abc" {
+		t.Errorf("Capture(&s).Matches(1) should neither match nor capture, captured %q", s)
+	}
+	if m.Matches(nil) {
+		t.Error("Capture(&s).Matches(nil) should not match")
+	}
+
+	err := io.EOF
+	if e := gomock.Capture(&err); !e.Matches(nil) || err != nil {
+		t.Errorf("Capture(&err).Matches(nil) should match and capture nil, captured %v", err)
+	}
+
+	if got, want := m.String(), "captures string"; got != want {
+		t.Errorf("got string = %q, want string = %q", got, want)
+	}
+	var r io.Reader
+	if got, want := gomock.Capture(&r).String(), "captures io.Reader"; got != want {
+		t.Errorf("got string = %q, want string = %q", got, want)
+	}
+}
+
+func TestSameMatcher(t *testing.T) {
+	a, b := new(int), new(int)
+	first := &struct{ n int }{}
+	ch := make(chan int)
+	m := map[string]int{}
+
+	for _, tt := range []struct {
+		name    string
+		matcher gomock.Matcher
+		x       any
+		want    bool
+	}{
+		{"same pointer", gomock.Same(a), a, true},
+		{"equal but distinct pointer", gomock.Same(a), b, false},
+		{"pointee value", gomock.Same(a), 0, false},
+		{"same address as other type", gomock.Same(first), &first.n, false},
+		{"nil", gomock.Same(a), nil, false},
+		{"same channel", gomock.Same(ch), ch, true},
+		{"other channel", gomock.Same(ch), make(chan int), false},
+		{"same map", gomock.Same(m), m, true},
+		{"other map", gomock.Same(m), map[string]int{}, false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.matcher.Matches(tt.x); got != tt.want {
+				t.Errorf("%v.Matches(%v) = %v, want %v", tt.matcher, tt.x, got, tt.want)
+			}
+		})
+	}
+
+	if got, want := gomock.Same(a).String(), fmt.Sprintf("is the same instance as %p (*int)", a); got != want {
+		t.Errorf("got string = %q, want string = %q", got, want)
+	}
+}
+
+func TestSameMatcher_InvalidArgument(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Same(1) should panic")
+		}
+	}()
+	gomock.Same(1)
+}
+
+func TestNotCancelledContextMatcher(t *testing.T) {
+	live, cancelLive := context.WithCancel(context.Background())
+	defer cancelLive()
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+
+	m := gomock.NotCancelledContext()
+	for _, tt := range []struct {
+		name string
+		x    any
+		want bool
+	}{
+		{"background", context.Background(), true},
+		{"live", live, true},
+		{"cancelled", cancelled, false},
+		{"past deadline", expired, false},
+		{"nil", nil, false},
+		{"not a context", "ctx", false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.Matches(tt.x); got != tt.want {
+				t.Errorf("Matches(%v) = %v, want %v", tt.x, got, tt.want)
+			}
+		})
+	}
+	if got, want := m.String(), "is a live (non-cancelled) context"; got != want {
+		t.Errorf("got string = %q, want string = %q", got, want)
+	}
+}
+
+func TestContextWithDeadlineWithinMatcher(t *testing.T) {
+	short, cancelShort := context.WithTimeout(context.Background(), time.Second)
+	defer cancelShort()
+	long, cancelLong := context.WithTimeout(context.Background(), time.Hour)
+	defer cancelLong()
+
+	m := gomock.ContextWithDeadlineWithin(time.Minute)
+	for _, tt := range []struct {
+		name     string
+		x        any
+		want     bool
+		wantDiff string
+	}{
+		{"deadline within", short, true, ""},
+		{"deadline beyond", long, false, "context deadline is "},
+		{"no deadline", context.Background(), false, "context has no deadline"},
+		{"nil", nil, false, "<nil> (<nil>) is not a context"},
+		{"not a context", "ctx", false, "ctx (string) is not a context"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.Matches(tt.x); got != tt.want {
+				t.Errorf("Matches(%v) = %v, want %v", tt.x, got, tt.want)
+			}
+			if tt.want {
+				return
+			}
+			if got := m.(gomock.Differ).Diff(tt.x); !strings.HasPrefix(got, tt.wantDiff) {
+				t.Errorf("Diff(%v) = %q, want prefix %q", tt.x, got, tt.wantDiff)
+			}
+		})
+	}
+	if got, want := m.String(), "has a deadline within 1m0s"; got != want {
+		t.Errorf("got string = %q, want string = %q", got, want)
+	}
+}
+
+func TestDurationApproxMatcher(t *testing.T) {
+	m := gomock.DurationApprox(time.Second, 100*time.Millisecond)
+	for _, tt := range []struct {
+		name string
+		x    any
+		want bool
+	}{
+		{"same", time.Second, true},
+		{"within tolerance", 950 * time.Millisecond, true},
+		{"at tolerance", 1100 * time.Millisecond, true},
+		{"beyond tolerance", 1101 * time.Millisecond, false},
+		{"beyond tolerance below", 899 * time.Millisecond, false},
+		{"not a duration", int64(time.Second), false},
+		{"nil", nil, false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.Matches(tt.x); got != tt.want {
+				t.Errorf("Matches(%v) = %v, want %v", tt.x, got, tt.want)
+			}
+		})
+	}
+
+	if got := gomock.DurationApprox(time.Second, -time.Millisecond).Matches(time.Second + time.Millisecond); !got {
+		t.Error("a negative tolerance should be treated as its absolute value")
+	}
+	if got, want := m.String(), "is within 100ms of 1s"; got != want {
+		t.Errorf("got string = %q, want string = %q", got, want)
+	}
+}
+
+func TestEqIgnoringMatcher(t *testing.T) {
+	type meta struct {
+		Owner     string
+		UpdatedAt time.Time
+	}
+	type event struct {
+		ID        int
+		CreatedAt time.Time
+		Meta      meta
+		source    string
+	}
+	t1 := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+	want := event{ID: 1, CreatedAt: t1, Meta: meta{Owner: "a", UpdatedAt: t1}, source: "api"}
+
+	tests := []struct {
+		name    string
+		matcher gomock.Matcher
+		x       any
+		want    bool
+	}{
+		{"ignored field differs", gomock.EqIgnoring(want, "CreatedAt"), event{ID: 1, CreatedAt: t2, Meta: want.Meta, source: "api"}, true},
+		{"other field differs", gomock.EqIgnoring(want, "CreatedAt"), event{ID: 2, CreatedAt: t2, Meta: want.Meta, source: "api"}, false},
+		{"unexported field differs", gomock.EqIgnoring(want, "CreatedAt"), event{ID: 1, CreatedAt: t2, Meta: want.Meta, source: "cli"}, false},
+		{"unexported field ignored", gomock.EqIgnoring(want, "source"), event{ID: 1, CreatedAt: t1, Meta: want.Meta, source: "cli"}, true},
+		{"nested field not ignored", gomock.EqIgnoring(want, "CreatedAt"), event{ID: 1, CreatedAt: t1, Meta: meta{Owner: "a", UpdatedAt: t2}, source: "api"}, false},
+		{"nested field ignored", gomock.EqIgnoring(want, "CreatedAt", "Meta.UpdatedAt"), event{ID: 1, CreatedAt: t2, Meta: meta{Owner: "a", UpdatedAt: t2}, source: "api"}, true},
+		{"pointer", gomock.EqIgnoring(&want, "CreatedAt"), &event{ID: 1, CreatedAt: t2, Meta: want.Meta, source: "api"}, true},
+		{"other type", gomock.EqIgnoring(want, "CreatedAt"), 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.matcher.Matches(tt.x); got != tt.want {
+				t.Errorf("got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+
+	got := gomock.EqIgnoring(want, "CreatedAt", "Meta.UpdatedAt").String()
+	if suffix := "ignoring fields CreatedAt, Meta.UpdatedAt"; !strings.HasSuffix(got, suffix) {
+		t.Errorf("got string = %q, want suffix %q", got, suffix)
+	}
+
+	for _, tt := range []struct {
+		x     any
+		field string
+	}{{1, "ID"}, {want, "Missing"}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("EqIgnoring(%v, %q) didn't panic", tt.x, tt.field)
+				}
+			}()
+			gomock.EqIgnoring(tt.x, tt.field)
+		}()
+	}
+}
+
+type intSlice []int
+
+func TestTimeEqMatcher(t *testing.T) {
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		tokyo = time.FixedZone("JST", 9*60*60)
+	}
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		matcher gomock.Matcher
+		x       any
+		want    bool
+	}{
+		{"same", gomock.TimeEq(base, 0), base, true},
+		{"within tolerance", gomock.TimeEq(base, time.Second), base.Add(999 * time.Millisecond), true},
+		{"within tolerance before", gomock.TimeEq(base, time.Second), base.Add(-time.Second), true},
+		{"beyond tolerance", gomock.TimeEq(base, time.Second), base.Add(1001 * time.Millisecond), false},
+		{"beyond tolerance before", gomock.TimeEq(base, time.Second), base.Add(-2 * time.Second), false},
+		{"other location", gomock.TimeEq(base, 0), base.In(tokyo), true},
+		{"monotonic reading", gomock.TimeEq(now.Round(0), 0), now, true},
+		{"not a time", gomock.TimeEq(base, time.Hour), base.Unix(), false},
+		{"nil", gomock.TimeEq(base, time.Hour), nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.matcher.Matches(tt.x); got != tt.want {
+				t.Errorf("got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+
+	want := "is within 1s of 2024-03-01 12:00:00 +0000 UTC"
+	if got := gomock.TimeEq(base, time.Second).String(); got != want {
+		t.Errorf("got string = %q, want string = %q", got, want)
+	}
+}
+
+func TestAnyOfTypeMatcher(t *testing.T) {
+	ints := reflect.TypeOf([]int(nil))
+
+	for _, tt := range []struct {
+		name    string
+		matcher gomock.Matcher
+		x       any
+		want    bool
+	}{
+		{"exact type", gomock.AnyOfType(ints), []int{1}, true},
+		{"assignable named type", gomock.AnyOfType(ints), intSlice{1}, false},
+		{"assignable named type with AssignableToType", gomock.AssignableToType(ints), intSlice{1}, true},
+		{"other type", gomock.AnyOfType(ints), "abc", false},
+		{"untyped nil", gomock.AnyOfType(ints), nil, false},
+		{"generic exact type", gomock.AnyType[intSlice](), intSlice{1}, true},
+		{"generic underlying type", gomock.AnyType[intSlice](), []int{1}, false},
+		{"generic interface type", gomock.AnyType[io.Reader](), &bytes.Buffer{}, true},
+		{"generic interface type not implemented", gomock.AnyType[io.Reader](), bytes.Buffer{}, false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.matcher.Matches(tt.x); got != tt.want {
+				t.Errorf("%v.Matches(%#v) = %v, want %v", tt.matcher, tt.x, got, tt.want)
+			}
+		})
+	}
+
+	if got, want := gomock.AnyOfType(ints).String(), "is any []int"; got != want {
+		t.Errorf("got string = %q, want string = %q", got, want)
+	}
+	if got, want := gomock.AnyType[io.Reader]().String(), "is any io.Reader"; got != want {
+		t.Errorf("got string = %q, want string = %q", got, want)
+	}
+}
+
+type valueCloser struct{}
+
+func (valueCloser) Close() error { return nil }
+
+type pointerCloser struct{}
+
+func (*pointerCloser) Close() error { return nil }
+
+func TestSortedMatcher(t *testing.T) {
+	matcher := gomock.Sorted(func(a, b any) bool { return a.(int) < b.(int) })
+
+	tests := []struct {
+		name string
+		x    any
+		want bool
+	}{
+		{"sorted", []int{1, 2, 2, 3}, true},
+		{"unsorted", []int{1, 3, 2}, false},
+		{"empty", []int{}, true},
+		{"nil slice", []int(nil), true},
+		{"array", [3]int{1, 2, 3}, true},
+		{"not a slice", 1, false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matcher.Matches(tt.x); got != tt.want {
+				t.Errorf("got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+
+	if got, want := matcher.String(), "is sorted"; got != want {
+		t.Errorf("got string = %q, want string = %q", got, want)
+	}
+}
+
+func TestSameFuncMatcher(t *testing.T) {
+	matcher := gomock.SameFunc(strings.ToUpper)
+
+	tests := []struct {
+		name string
+		x    any
+		want bool
+	}{
+		{"same function", strings.ToUpper, true},
+		{"different function", strings.ToLower, false},
+		{"different type", strings.Repeat, false},
+		{"nil function", (func(string) string)(nil), false},
+		{"not a function", "ToUpper", false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matcher.Matches(tt.x); got != tt.want {
+				t.Errorf("got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+
+	if got, want := matcher.String(), "is the same function"; got != want {
+		t.Errorf("got string = %q, want string = %q", got, want)
+	}
+}
+
+func TestSameFuncMatcherInvalid(t *testing.T) {
+	for _, fn := range []any{nil, "ToUpper", (func())(nil)} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("SameFunc(%#v) didn't panic", fn)
+				}
+			}()
+			gomock.SameFunc(fn)
+		}()
+	}
+}
+
+func TestAnyChanMatcher(t *testing.T) {
+	type events chan int
+	intType := reflect.TypeOf(0)
+
+	tests := []struct {
+		name    string
+		matcher gomock.Matcher
+		x       any
+		want    bool
+	}{
+		{"chan int", gomock.AnyChan(intType, reflect.BothDir), make(chan int), true},
+		{"named chan int", gomock.AnyChan(intType, reflect.BothDir), make(events), true},
+		{"nil chan int", gomock.AnyChan(intType, reflect.BothDir), (chan int)(nil), true},
+		{"receive-only for chan int", gomock.AnyChan(intType, reflect.BothDir), make(<-chan int), false},
+		{"chan string for chan int", gomock.AnyChan(intType, reflect.BothDir), make(chan string), false},
+		{"receive-only", gomock.AnyChan(intType, reflect.RecvDir), make(<-chan int), true},
+		{"chan int for receive-only", gomock.AnyChan(intType, reflect.RecvDir), make(chan int), false},
+		{"send-only", gomock.AnyChan(intType, reflect.SendDir), make(chan<- int), true},
+		{"not a chan", gomock.AnyChan(intType, reflect.BothDir), []int{}, false},
+		{"nil", gomock.AnyChan(intType, reflect.BothDir), nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.matcher.Matches(tt.x); got != tt.want {
+				t.Errorf("got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+
+	for dir, want := range map[reflect.ChanDir]string{
+		reflect.BothDir: "is a chan int",
+		reflect.RecvDir: "is a <-chan int",
+		reflect.SendDir: "is a chan<- int",
+	} {
+		if got := gomock.AnyChan(intType, dir).String(); got != want {
+			t.Errorf("got string = %q, want string = %q", got, want)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("AnyChan with an invalid direction did not panic")
+		}
+	}()
+	gomock.AnyChan(intType, 0)
+}
+
+func TestImplementsMatcher(t *testing.T) {
+	matcher := gomock.Implements((*io.Closer)(nil))
+
+	tests := []struct {
+		name      string
+		input     any
+		wantMatch bool
+	}{
+		{"value receiver by value", valueCloser{}, true},
+		{"value receiver by pointer", &valueCloser{}, true},
+		{"pointer receiver by value", pointerCloser{}, false},
+		{"pointer receiver by pointer", &pointerCloser{}, true},
+		{"non-closer", &bytes.Buffer{}, false},
+		{"untyped nil", nil, false},
+		{"typed nil pointer", (*pointerCloser)(nil), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matcher.Matches(tt.input); got != tt.wantMatch {
+				t.Errorf("got = %v, wantMatch = %v", got, tt.wantMatch)
+			}
+		})
+	}
+
+	if got, want := matcher.String(), "implements io.Closer"; got != want {
+		t.Errorf("got string = %q, want string = %q", got, want)
+	}
+}
+
+func TestImplementsMatcher_InvalidArgument(t *testing.T) {
+	for _, arg := range []any{nil, io.Closer(nil), valueCloser{}, new(int)} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Implements(%T) did not panic", arg)
+				}
+			}()
+			gomock.Implements(arg)
+		}()
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestPointeeMatcher_String(t *testing.T) {
+	if got, want := gomock.Pointee(gomock.Eq(5)).String(), "points to is equal to 5 (int)"; got != want {
+		t.Errorf("got string = %q, want string = %q", got, want)
+	}
+}
+
+func TestFieldsEqMatcher(t *testing.T) {
+	matcher := gomock.FieldsEq(map[string]any{"Name": "Fido", "Breed": "pug"})
+
+	wantStr := "has fields {Breed: is equal to pug (string), Name: is equal to Fido (string)}"
+	if got := matcher.String(); got != wantStr {
+		t.Errorf("got string = %q, want string = %q", got, wantStr)
+	}
+
+	wantDiff := "\nName: got: Rex (string), want: is equal to Fido (string)"
+	if got := matcher.(gomock.Differ).Diff(Dog{Breed: "pug", Name: "Rex"}); got != wantDiff {
+		t.Errorf("got diff = %q, want diff = %q", got, wantDiff)
+	}
+
+	// Fields promoted through a nil embedded pointer don't match.
+	type owner struct{ Owner string }
+	type pet struct {
+		*owner
+		Name string
+	}
+	byOwner := gomock.FieldsEq(map[string]any{"Owner": "Ann"})
+	if !byOwner.Matches(pet{owner: &owner{"Ann"}}) {
+		t.Error("FieldsEq didn't match a promoted field")
+	}
+	if byOwner.Matches(pet{}) {
+		t.Error("FieldsEq matched a field promoted through a nil pointer")
+	}
+	wantDiff = "\nOwner: reflect: indirection through nil pointer to embedded struct field owner"
+	if got := byOwner.(gomock.Differ).Diff(pet{}); got != wantDiff {
+		t.Errorf("got diff = %q, want diff = %q", got, wantDiff)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("FieldsEq with an unexported field name did not panic")
+		}
+	}()
+	gomock.FieldsEq(map[string]any{"name": "Fido"})
+}
+
+func TestFieldMatcher(t *testing.T) {
+	type address struct {
+		City string
+		zip  string
+	}
+	type user struct {
+		Name    string
+		Address *address
+	}
+	type request struct {
+		User user
+	}
+	type located struct {
+		*address
+	}
+
+	matcher := gomock.Field("User.Address.City", "Paris")
+	if got, want := matcher.String(), "field User.Address.City matches is equal to Paris (string)"; got != want {
+		t.Errorf("got string = %q, want string = %q", got, want)
+	}
+
+	tests := []struct {
+		name    string
+		matcher gomock.Matcher
+		x       any
+		want    bool
+	}{
+		{"match", matcher, request{User: user{Address: &address{City: "Paris"}}}, true},
+		{"match through pointer", matcher, &request{User: user{Address: &address{City: "Paris"}}}, true},
+		{"mismatch", matcher, request{User: user{Address: &address{City: "Rome"}}}, false},
+		{"nil pointer", matcher, request{}, false},
+		{"not a struct", matcher, "Paris", false},
+		{"nil", matcher, nil, false},
+		{"matcher", gomock.Field("User.Name", gomock.Regex("^A")), request{User: user{Name: "Ann"}}, true},
+		{"missing field", gomock.Field("User.Email", gomock.Any()), request{}, false},
+		{"unexported field", gomock.Field("User.Address.zip", gomock.Any()), request{User: user{Address: &address{}}}, false},
+		{"promoted field", gomock.Field("City", "Paris"), located{&address{City: "Paris"}}, true},
+		{"nil embedded pointer", gomock.Field("City", "Paris"), located{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.matcher.Matches(tt.x); got != tt.want {
+				t.Errorf("got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+
+	diffs := []struct {
+		matcher gomock.Matcher
+		x       any
+		want    string
+	}{
+		{matcher, request{User: user{Address: &address{City: "Rome"}}}, "User.Address.City: got: Rome (string), want: is equal to Paris (string)"},
+		{matcher, request{}, "can't get field City of a nil *gomock_test.address"},
+		{gomock.Field("User.Email", gomock.Any()), request{}, "gomock_test.user has no exported field Email"},
+		{gomock.Field("City", "Paris"), located{}, "can't get field City of gomock_test.located: reflect: indirection through nil pointer to embedded struct field address"},
+	}
+	for _, d := range diffs {
+		if got := d.matcher.(gomock.Differ).Diff(d.x); got != d.want {
+			t.Errorf("got diff = %q, want diff = %q", got, d.want)
+		}
+	}
+}
+
+func TestMapContainingMatcher(t *testing.T) {
+	matcher := gomock.MapContaining(map[string]any{"b": gomock.Regex("^x"), "a": 1})
+
+	wantStr := `contains entries {a: is equal to 1 (int), b: matches regex ^x}`
+	if got := matcher.String(); got != wantStr {
+		t.Errorf("got string = %q, want string = %q", got, wantStr)
+	}
+
+	tests := []struct {
+		name string
+		x    any
+		want bool
+	}{
+		{"superset", map[string]any{"a": 1, "b": "xyz", "c": true}, true},
+		{"exact", map[string]any{"a": 1, "b": "x"}, true},
+		{"typed map", map[string]int{"a": 1, "b": 2}, false},
+		{"missing key", map[string]any{"a": 1}, false},
+		{"wrong value", map[string]any{"a": 2, "b": "x"}, false},
+		{"wrong key type", map[int]any{1: 1}, false},
+		{"not a map", "a", false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matcher.Matches(tt.x); got != tt.want {
+				t.Errorf("got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+
+	wantDiff := "\nmissing key b"
+	if got := matcher.(gomock.Differ).Diff(map[string]any{"a": 1, "c": 3}); got != wantDiff {
+		t.Errorf("got diff = %q, want diff = %q", got, wantDiff)
+	}
+	wantDiff = "\na: got: 2 (int), want: is equal to 1 (int)"
+	if got := matcher.(gomock.Differ).Diff(map[string]any{"a": 2, "b": "x"}); got != wantDiff {
+		t.Errorf("got diff = %q, want diff = %q", got, wantDiff)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MapContaining with a non-map subset did not panic")
+		}
+	}()
+	gomock.MapContaining([]string{"a"})
+}
+
+func TestMapContainingKeysMatcher(t *testing.T) {
+	matcher := gomock.MapContainingKeys("a", "b")
+
+	if got, want := matcher.String(), "contains keys [a b]"; got != want {
+		t.Errorf("got string = %q, want string = %q", got, want)
+	}
+
+	tests := []struct {
+		name string
+		x    any
+		want bool
+	}{
+		{"all keys", map[string]int{"a": 1, "b": 2, "c": 3}, true},
+		{"any values", map[string]any{"a": nil, "b": struct{}{}}, true},
+		{"missing key", map[string]int{"a": 1}, false},
+		{"wrong key type", map[int]int{1: 1}, false},
+		{"not a map", []string{"a", "b"}, false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matcher.Matches(tt.x); got != tt.want {
+				t.Errorf("got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+
+	wantDiff := "\nmissing key b"
+	if got := matcher.(gomock.Differ).Diff(map[string]int{"a": 1}); got != wantDiff {
+		t.Errorf("got diff = %q, want diff = %q", got, wantDiff)
+	}
+	wantDiff = "got [a b] ([]string), want a map"
+	if got := matcher.(gomock.Differ).Diff([]string{"a", "b"}); got != wantDiff {
+		t.Errorf("got diff = %q, want diff = %q", got, wantDiff)
+	}
+}
+
+func TestNotNilMatcher_String(t *testing.T) {
+	if got, want := gomock.NotNil().String(), "is not nil"; got != want {
+		t.Errorf("got string = %q, want string = %q", got, want)
+	}
+}
+
+type color int
+
+func (c color) String() string {
+	return [...]string{"red", "green"}[c]
+}
+
+func TestStringerEqMatcher(t *testing.T) {
+	matcher := gomock.StringerEq("green")
+
+	tests := []struct {
+		name string
+		x    any
+		want bool
+	}{
+		{"equal", color(1), true},
+		{"not equal", color(0), false},
+		{"string", "green", false},
+		{"underlying type", 1, false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matcher.Matches(tt.x); got != tt.want {
+				t.Errorf("got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+
+	if got, want := matcher.String(), `stringer equals "green"`; got != want {
+		t.Errorf("got string = %q, want string = %q", got, want)
+	}
+	gf := matcher.(gomock.GotFormatter)
+	if got, want := gf.Got(color(0)), `"red" (gomock_test.color)`; got != want {
+		t.Errorf("got Got = %q, want %q", got, want)
+	}
+	if got, want := gf.Got(1), "1 (int), which is not a fmt.Stringer"; got != want {
+		t.Errorf("got Got = %q, want %q", got, want)
+	}
+}
+
+func TestWhenMatcher(t *testing.T) {
+	enabled := false
+	matcher := gomock.When(func() bool { return enabled }, 5)
+
+	if matcher.Matches(5) {
+		t.Error("When should not match while the condition is false")
+	}
+	enabled = true
+	if !matcher.Matches(5) {
+		t.Error("When should match a matching value while the condition is true")
+	}
+	if matcher.Matches(4) {
+		t.Error("When should not match a mismatching value while the condition is true")
+	}
+	enabled = false
+	if matcher.Matches(5) {
+		t.Error("When should not match once the condition is false again")
+	}
+
+	if got, want := matcher.String(), "when custom condition then is equal to 5 (int)"; got != want {
+		t.Errorf("got string = %q, want string = %q", got, want)
+	}
+	if got, want := gomock.When(func() bool { return true }, gomock.Any()).String(), "when custom condition then is anything"; got != want {
+		t.Errorf("got string = %q, want string = %q", got, want)
+	}
+}
+
+// version has an Equal method that ignores its label, and unexported fields
+// that cmp.Equal would panic on.
+type version struct {
+	major, minor int
+	label        string
+}
+
+func (v version) Equal(o version) bool {
+	return v.major == o.major && v.minor == o.minor
+}
+
+func TestEqualMethodMatcher(t *testing.T) {
+	type point struct{ x, y int }
+
+	tests := []struct {
+		name string
+		want any
+		x    any
+		ok   bool
+	}{
+		{"Equal method", version{1, 2, "stable"}, version{1, 2, "beta"}, true},
+		{"Equal method mismatch", version{1, 2, "stable"}, version{1, 3, "stable"}, false},
+		{"different type", version{1, 2, ""}, "1.2", false},
+		{"time.Time", time.Unix(0, 0), time.Unix(0, 0).UTC(), true},
+		{"no Equal method", 1, 1, true},
+		{"no Equal method mismatch", 1, 2, false},
+		{"unexported fields", point{1, 2}, point{1, 2}, true},
+		{"unexported fields mismatch", point{1, 2}, point{2, 1}, false},
+		{"nil", version{}, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gomock.EqualMethod(tt.want).Matches(tt.x); got != tt.ok {
+				t.Errorf("got = %v, want = %v", got, tt.ok)
+			}
+		})
+	}
+
+	if got, want := gomock.EqualMethod(version{1, 2, "stable"}).String(), "equals (via Equal method) {1 2 stable}"; got != want {
+		t.Errorf("got string = %q, want string = %q", got, want)
+	}
+}
+
+func TestEqMatcherMapDiff(t *testing.T) {
+	want := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}
+	got := map[string]int{"a": 1, "b": 20, "c": 3, "e": 5}
+
+	d := gomock.Eq(want).(gomock.Differ)
+	wantDiff := "\nb: got: 20, want: 2\nmissing key d: want: 4\nunexpected key e: got: 5"
+	if diff := d.Diff(got); diff != wantDiff {
+		t.Errorf("got diff = %q, want diff = %q", diff, wantDiff)
+	}
+
+	// Values of different types are diffed as usual.
+	if diff := d.Diff(map[string]int64{"a": 1}); !strings.Contains(diff, "map[string]int") {
+		t.Errorf("got diff = %q, want a go-cmp diff", diff)
+	}
+
+	// Maps without differing keys, such as nil and empty, are diffed as usual.
+	if diff := gomock.Eq(map[string]int{}).(gomock.Differ).Diff(map[string]int(nil)); !strings.Contains(diff, "map[string]int") {
+		t.Errorf("got diff = %q, want a go-cmp diff", diff)
+	}
+}
+
+func TestEqualFoldMatcher(t *testing.T) {
+	matcher := gomock.EqualFold("Content-Type")
+
+	tests := []struct {
+		name string
+		x    any
+		want bool
+	}{
+		{"same case", "Content-Type", true},
+		{"lower case", "content-type", true},
+		{"bytes", []byte("CONTENT-TYPE"), true},
+		{"mismatch", "Content-Length", false},
+		{"bytes mismatch", []byte("content-length"), false},
+		{"not a string", 42, false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matcher.Matches(tt.x); got != tt.want {
+				t.Errorf("got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+
+	if got, want := matcher.String(), `equals (case-insensitive) "Content-Type"`; got != want {
+		t.Errorf("got string = %q, want string = %q", got, want)
+	}
+}
+
+func TestTruthyFalsyMatchers(t *testing.T) {
+	var nilPtr *int
+	tests := []struct {
+		name   string
+		x      any
+		truthy bool
+	}{
+		{"true", true, true},
+		{"false", false, false},
+		{"non-zero int", -1, true},
+		{"zero int", 0, false},
+		{"non-zero float", 0.5, true},
+		{"zero float", 0.0, false},
+		{"non-empty string", "a", true},
+		{"empty string", "", false},
+		{"non-empty slice", []int{0}, true},
+		{"empty slice", []int{}, false},
+		{"nil slice", []int(nil), false},
+		{"non-empty map", map[string]int{"a": 0}, true},
+		{"empty map", map[string]int{}, false},
+		{"non-nil pointer", new(int), true},
+		{"nil pointer", nilPtr, false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gomock.Truthy().Matches(tt.x); got != tt.truthy {
+				t.Errorf("Truthy().Matches(%#v) = %v, want %v", tt.x, got, tt.truthy)
+			}
+			if got := gomock.Falsy().Matches(tt.x); got == tt.truthy {
+				t.Errorf("Falsy().Matches(%#v) = %v, want %v", tt.x, got, !tt.truthy)
+			}
+		})
+	}
+
+	if got, want := gomock.Truthy().String(), "is truthy"; got != want {
+		t.Errorf("got string = %q, want string = %q", got, want)
+	}
+	if got, want := gomock.Falsy().String(), "is falsy"; got != want {
+		t.Errorf("got string = %q, want string = %q", got, want)
+	}
+}
+
+func TestEmptyMatcher_String(t *testing.T) {
+	if got, want := gomock.Empty().String(), "is empty"; got != want {
+		t.Errorf("got string = %q, want string = %q", got, want)
+	}
+	if got, want := gomock.NotEmpty().String(), "is not empty"; got != want {
+		t.Errorf("got string = %q, want string = %q", got, want)
+	}
+}
+
+type numberAbove struct {
+	min int
+}
+
+func (m numberAbove) Matches(x TestStruct) bool {
+	return x.Number > m.min
+}
+
+func (m numberAbove) String() string {
+	return fmt.Sprintf("has Number above %d", m.min)
+}
+
+type nilOrEOF struct{}
+
+func (nilOrEOF) Matches(err error) bool {
+	return err == nil || err == io.EOF
+}
+
+func (nilOrEOF) String() string {
+	return "is nil or EOF"
+}
+
+func TestTypedMatcher(t *testing.T) {
+	matcher := gomock.Typed[TestStruct](numberAbove{min: 1})
+
+	if !matcher.Matches(TestStruct{Number: 2}) {
+		t.Errorf("Typed matcher should match TestStruct{Number: 2}")
+	}
+	if matcher.Matches(TestStruct{Number: 1}) {
+		t.Errorf("Typed matcher should not match TestStruct{Number: 1}")
+	}
+	if matcher.Matches(&TestStruct{Number: 2}) {
+		t.Errorf("Typed matcher should not match a value of another type")
+	}
+	if matcher.Matches(nil) {
+		t.Errorf("Typed matcher should not match nil for a non-nillable type")
+	}
+	if got, want := matcher.String(), "has Number above 1"; got != want {
+		t.Errorf("got string = %q, want string = %q", got, want)
+	}
+
+	errMatcher := gomock.Typed[error](nilOrEOF{})
+	if !errMatcher.Matches(nil) {
+		t.Errorf("Typed[error] matcher should receive nil as a nil error")
+	}
+	if !errMatcher.Matches(io.EOF) {
+		t.Errorf("Typed[error] matcher should match io.EOF")
+	}
+}
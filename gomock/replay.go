@@ -0,0 +1,233 @@
+package gomock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+)
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// recordedCall is the serialized form of a call, as written by Recorder and
+// read by LoadExpectations.
+type recordedCall struct {
+	Receiver string            `json:"receiver"`
+	Method   string            `json:"method"`
+	Args     []json.RawMessage `json:"args"`
+	Returns  []json.RawMessage `json:"returns"`
+}
+
+// Recorder records calls made to real implementations, so that they can be
+// replayed later as expectations with LoadExpectations.
+//
+// The recording is a JSON array with one object per call, in the order the
+// calls were made:
+//
+//	[
+//	  {"receiver": "store", "method": "Get", "args": ["a"], "returns": [1, null]}
+//	]
+//
+// Arguments and results are encoded with encoding/json, so they must be
+// values that round-trip through it: booleans, numbers, strings, and slices,
+// maps, structs and pointers made of those. Two interface types are handled
+// specially:
+//   - error values are encoded as their message, or null for a nil error,
+//     and loaded as errors created with errors.New.
+//   - context.Context arguments are encoded as null, and loaded as Any().
+//
+// Values of any other interface type, channels and funcs are not supported.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []recorderCall
+}
+
+type recorderCall struct {
+	receiver string
+	method   string
+	ft       reflect.Type
+	args     []any
+	rets     []any
+}
+
+// NewRecorder returns an empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Call calls method on impl with args, records the call under the receiver
+// name and returns the method's results. name identifies the receiver in
+// LoadExpectations, so it should be unique within a recording. Call panics if
+// impl has no exported method named method.
+//
+// Example usage, in a wrapper around a real implementation:
+//
+//	func (s *recordingStore) Get(key string) (int, error) {
+//	  rets := s.rec.Call("store", s.real, "Get", key)
+//	  n, _ := rets[0].(int)
+//	  err, _ := rets[1].(error)
+//	  return n, err
+//	}
+func (r *Recorder) Call(name string, impl any, method string, args ...any) []any {
+	ft := methodType(impl, method)
+	if ft == nil {
+		panic(fmt.Sprintf("gomock: Recorder.Call: %T has no method %s", impl, method))
+	}
+	vRets := reflect.ValueOf(impl).MethodByName(method).Call(funcArgs(ft, args))
+	rets := make([]any, len(vRets))
+	for i, ret := range vRets {
+		rets[i] = ret.Interface()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, recorderCall{receiver: name, method: method, ft: ft, args: args, rets: rets})
+	return rets
+}
+
+// WriteTo writes the calls recorded so far to w. It returns an error without
+// writing anything if an argument or result can't be encoded.
+func (r *Recorder) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	calls := make([]recordedCall, len(r.calls))
+	for i, c := range r.calls {
+		rc := recordedCall{Receiver: c.receiver, Method: c.method}
+		for j, arg := range c.args {
+			data, err := encodeValue(paramType(c.ft, j), arg)
+			if err != nil {
+				return 0, fmt.Errorf("gomock: can't encode argument %d of call %d to %s.%s: %w", j, i, c.receiver, c.method, err)
+			}
+			rc.Args = append(rc.Args, data)
+		}
+		for j, ret := range c.rets {
+			data, err := encodeValue(c.ft.Out(j), ret)
+			if err != nil {
+				return 0, fmt.Errorf("gomock: can't encode result %d of call %d to %s.%s: %w", j, i, c.receiver, c.method, err)
+			}
+			rc.Returns = append(rc.Returns, data)
+		}
+		calls[i] = rc
+	}
+
+	data, err := json.MarshalIndent(calls, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(append(data, '\n'))
+	return int64(n), err
+}
+
+// LoadExpectations reads calls in the format written by Recorder from r, and
+// records each as an expected call on ctrl, to be made once with the same
+// arguments and returning the same results. receivers maps the receiver
+// names used in the recording to the mocks the calls are expected on.
+// Expectations are recorded in the order of the recording.
+//
+// LoadExpectations returns an error, without recording any call, if r can't
+// be decoded, a receiver is missing from receivers, a call has the wrong
+// number of arguments or results for the mocked method, or a value can't be
+// loaded into the type of the method's parameter or result.
+func LoadExpectations(ctrl *Controller, r io.Reader, receivers map[string]any) error {
+	var calls []recordedCall
+	if err := json.NewDecoder(r).Decode(&calls); err != nil {
+		return fmt.Errorf("gomock: can't decode expectations: %w", err)
+	}
+
+	type expectation struct {
+		receiver any
+		method   string
+		ft       reflect.Type
+		args     []any
+		rets     []any
+	}
+	exps := make([]expectation, len(calls))
+	for i, c := range calls {
+		receiver, ok := receivers[c.Receiver]
+		if !ok {
+			return fmt.Errorf("gomock: call %d is on unknown receiver %q", i, c.Receiver)
+		}
+		ft := methodType(receiver, c.Method)
+		if ft == nil {
+			return fmt.Errorf("gomock: call %d is to %T.%s, which doesn't exist", i, receiver, c.Method)
+		}
+		if ft.IsVariadic() {
+			if len(c.Args) < ft.NumIn()-1 {
+				return fmt.Errorf("gomock: call %d to %T.%s has %d arguments, want at least %d", i, receiver, c.Method, len(c.Args), ft.NumIn()-1)
+			}
+		} else if len(c.Args) != ft.NumIn() {
+			return fmt.Errorf("gomock: call %d to %T.%s has %d arguments, want %d", i, receiver, c.Method, len(c.Args), ft.NumIn())
+		}
+		if len(c.Returns) != ft.NumOut() {
+			return fmt.Errorf("gomock: call %d to %T.%s has %d results, want %d", i, receiver, c.Method, len(c.Returns), ft.NumOut())
+		}
+		exp := expectation{receiver: receiver, method: c.Method, ft: ft}
+		for j, data := range c.Args {
+			pt := paramType(ft, j)
+			if pt == contextType {
+				exp.args = append(exp.args, Any())
+				continue
+			}
+			arg, err := decodeValue(pt, data)
+			if err != nil {
+				return fmt.Errorf("gomock: can't load argument %d of call %d to %T.%s: %w", j, i, receiver, c.Method, err)
+			}
+			exp.args = append(exp.args, arg)
+		}
+		for j, data := range c.Returns {
+			ret, err := decodeValue(ft.Out(j), data)
+			if err != nil {
+				return fmt.Errorf("gomock: can't load result %d of call %d to %T.%s: %w", j, i, receiver, c.Method, err)
+			}
+			exp.rets = append(exp.rets, ret)
+		}
+		exps[i] = exp
+	}
+
+	ctrl.T.Helper()
+	for _, exp := range exps {
+		ctrl.RecordCallWithMethodType(exp.receiver, exp.method, exp.ft, exp.args...).Return(exp.rets...)
+	}
+	return nil
+}
+
+func encodeValue(t reflect.Type, v any) (json.RawMessage, error) {
+	switch {
+	case t == contextType || (nilMatcher{}).Matches(v):
+		// Typed nils, e.g. a (*T)(nil) returned as an error, are null too.
+		return json.RawMessage("null"), nil
+	case t == errorType:
+		return json.Marshal(v.(error).Error())
+	case t.Kind() == reflect.Interface:
+		return nil, fmt.Errorf("interface type %v is not supported", t)
+	}
+	return json.Marshal(v)
+}
+
+func decodeValue(t reflect.Type, data json.RawMessage) (any, error) {
+	switch {
+	case t == errorType:
+		var msg *string
+		if err := json.Unmarshal(data, &msg); err != nil {
+			return nil, err
+		}
+		if msg == nil {
+			return nil, nil
+		}
+		return errors.New(*msg), nil
+	case t.Kind() == reflect.Interface:
+		return nil, fmt.Errorf("interface type %v is not supported", t)
+	}
+	v := reflect.New(t)
+	if err := json.Unmarshal(data, v.Interface()); err != nil {
+		return nil, err
+	}
+	return v.Elem().Interface(), nil
+}
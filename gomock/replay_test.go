@@ -0,0 +1,218 @@
+package gomock_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+type inventory map[string]int
+
+func (inv inventory) Add(item string, n int) (int, error) {
+	if n <= 0 {
+		return inv[item], errors.New("n must be positive")
+	}
+	inv[item] += n
+	return inv[item], nil
+}
+
+func (inv inventory) Has(_ context.Context, item string) bool {
+	return inv[item] > 0
+}
+
+type stockError struct{ item string }
+
+func (e *stockError) Error() string { return e.item + " is out of stock" }
+
+// Check returns a typed nil when item is in stock, as some implementations do.
+func (inv inventory) Check(item string) error {
+	var err *stockError
+	if inv[item] == 0 {
+		err = &stockError{item}
+	}
+	return err
+}
+
+type mockInventory struct {
+	ctrl *gomock.Controller
+}
+
+func (m *mockInventory) Add(item string, n int) (int, error) {
+	ret := m.ctrl.Call(m, "Add", item, n)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (m *mockInventory) Has(ctx context.Context, item string) bool {
+	ret := m.ctrl.Call(m, "Has", ctx, item)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+func TestRecorderRoundTrip(t *testing.T) {
+	rec := gomock.NewRecorder()
+	inv := inventory{}
+	rec.Call("inventory", inv, "Add", "apple", 2)
+	rec.Call("inventory", inv, "Add", "apple", 0)
+	rec.Call("inventory", inv, "Has", context.Background(), "apple")
+	rec.Call("inventory", inv, "Has", context.Background(), "pear")
+
+	var buf bytes.Buffer
+	if _, err := rec.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo() = %v", err)
+	}
+	wantJSON := `{
+    "receiver": "inventory",
+    "method": "Add",
+    "args": [
+      "apple",
+      0
+    ],
+    "returns": [
+      2,
+      "n must be positive"
+    ]
+  }`
+	if !strings.Contains(buf.String(), wantJSON) {
+		t.Errorf("recording:\n%s\nwant it to contain:\n%s", buf.String(), wantJSON)
+	}
+
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter)
+	m := &mockInventory{ctrl}
+	if err := gomock.LoadExpectations(ctrl, &buf, map[string]any{"inventory": m}); err != nil {
+		t.Fatalf("LoadExpectations() = %v", err)
+	}
+
+	if n, err := m.Add("apple", 2); n != 2 || err != nil {
+		t.Errorf("Add(apple, 2) = %v, %v, want 2, nil", n, err)
+	}
+	if n, err := m.Add("apple", 0); n != 2 || err == nil || err.Error() != "n must be positive" {
+		t.Errorf("Add(apple, 0) = %v, %v, want 2, n must be positive", n, err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if !m.Has(ctx, "apple") {
+		t.Error("Has(apple) = false, want true")
+	}
+	if m.Has(ctx, "pear") {
+		t.Error("Has(pear) = true, want false")
+	}
+	ctrl.Finish()
+	reporter.assertPass("the replayed calls should match the recording")
+}
+
+func (m *mockInventory) Check(item string) error {
+	ret := m.ctrl.Call(m, "Check", item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func TestRecorderTypedNilError(t *testing.T) {
+	rec := gomock.NewRecorder()
+	inv := inventory{"apple": 1}
+	rec.Call("inventory", inv, "Check", "apple")
+	rec.Call("inventory", inv, "Check", "pear")
+
+	var buf bytes.Buffer
+	if _, err := rec.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo() = %v", err)
+	}
+
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter)
+	m := &mockInventory{ctrl}
+	if err := gomock.LoadExpectations(ctrl, &buf, map[string]any{"inventory": m}); err != nil {
+		t.Fatalf("LoadExpectations() = %v", err)
+	}
+	if err := m.Check("apple"); err != nil {
+		t.Errorf("Check(apple) = %v, want nil", err)
+	}
+	if err := m.Check("pear"); err == nil || err.Error() != "pear is out of stock" {
+		t.Errorf("Check(pear) = %v, want pear is out of stock", err)
+	}
+	ctrl.Finish()
+	reporter.assertPass("a typed nil error should be replayed as a nil error")
+}
+
+func TestRecorderUnexpectedReplay(t *testing.T) {
+	rec := gomock.NewRecorder()
+	rec.Call("inventory", inventory{}, "Add", "apple", 1)
+	var buf bytes.Buffer
+	if _, err := rec.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo() = %v", err)
+	}
+
+	reporter := NewErrorReporter(t)
+	ctrl := gomock.NewController(reporter)
+	m := &mockInventory{ctrl}
+	if err := gomock.LoadExpectations(ctrl, &buf, map[string]any{"inventory": m}); err != nil {
+		t.Fatalf("LoadExpectations() = %v", err)
+	}
+	reporter.assertFatal(func() {
+		m.Add("apple", 2)
+	}, "Unexpected call to", "doesn't match the argument at index 1")
+}
+
+func TestLoadExpectationsErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		recording string
+		wantErr   string
+	}{
+		{
+			"malformed",
+			`{`,
+			"can't decode expectations",
+		},
+		{
+			"unknown receiver",
+			`[{"receiver": "shop", "method": "Add", "args": ["apple", 1], "returns": [1, null]}]`,
+			`unknown receiver "shop"`,
+		},
+		{
+			"unknown method",
+			`[{"receiver": "inventory", "method": "Remove", "args": ["apple"], "returns": []}]`,
+			"Remove, which doesn't exist",
+		},
+		{
+			"wrong argument type",
+			`[{"receiver": "inventory", "method": "Add", "args": ["apple", "one"], "returns": [1, null]}]`,
+			"can't load argument 1",
+		},
+		{
+			"too few arguments",
+			`[{"receiver": "inventory", "method": "Add", "args": ["apple"], "returns": [1, null]}]`,
+			"has 1 arguments, want 2",
+		},
+		{
+			"too many arguments",
+			`[{"receiver": "inventory", "method": "Add", "args": ["apple", 1, 2], "returns": [1, null]}]`,
+			"has 3 arguments, want 2",
+		},
+		{
+			"wrong number of results",
+			`[{"receiver": "inventory", "method": "Add", "args": ["apple", 1], "returns": [1]}]`,
+			"has 1 results, want 2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reporter := NewErrorReporter(t)
+			ctrl := gomock.NewController(reporter)
+			m := &mockInventory{ctrl}
+			err := gomock.LoadExpectations(ctrl, strings.NewReader(tt.recording), map[string]any{"inventory": m})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadExpectations() = %v, want error containing %q", err, tt.wantErr)
+			}
+			if ctrl.HasExpectedCalls(m, "Add") {
+				t.Error("LoadExpectations recorded calls despite failing")
+			}
+		})
+	}
+}
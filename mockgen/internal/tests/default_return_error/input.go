@@ -0,0 +1,9 @@
+package default_return_error
+
+//go:generate mockgen -package default_return_error -source=input.go -destination=mock.go -default_return=error
+
+type Store interface {
+	Get(key string) (string, error)
+	Delete(keys ...string) error
+	Len() int
+}
@@ -0,0 +1,50 @@
+package default_return_error
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestErrorResultWithoutExpectation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := NewMockStore(ctrl)
+
+	if v, err := m.Get("a"); v != "" || !errors.Is(err, gomock.ErrNotMocked) {
+		t.Errorf("Get() = %q, %v, want %q, %v", v, err, "", gomock.ErrNotMocked)
+	}
+	if err := m.Delete("a", "b"); !errors.Is(err, gomock.ErrNotMocked) {
+		t.Errorf("Delete() = %v, want %v", err, gomock.ErrNotMocked)
+	}
+}
+
+func TestErrorResultWithExpectation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := NewMockStore(ctrl)
+	m.EXPECT().Get("a").Return("x", nil)
+
+	if v, err := m.Get("a"); v != "x" || err != nil {
+		t.Errorf("Get() = %q, %v, want %q, nil", v, err, "x")
+	}
+}
+
+// fatalReporter turns Fatalf into a panic so the test can observe it.
+type fatalReporter struct{ testing.TB }
+
+func (r fatalReporter) Fatalf(format string, args ...any) {
+	panic(fmt.Sprintf(format, args...))
+}
+
+func TestNoErrorResultWithoutExpectation(t *testing.T) {
+	ctrl := gomock.NewController(fatalReporter{t})
+	m := NewMockStore(ctrl)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Len() without an expected call did not fail the test")
+		}
+	}()
+	m.Len()
+}
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: input.go
+//
+// Generated by this command:
+//
+//	mockgen -source=input.go -destination=external/mock.go -delegate
+//
+
+// Package mock_delegate is a generated GoMock package.
+package mock_delegate
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+	delegate "go.uber.org/mock/mockgen/internal/tests/delegate"
+)
+
+// MockCache is a mock of Cache interface.
+type MockCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockCacheMockRecorder
+	delegate delegate.Cache
+}
+
+// MockCacheMockRecorder is the mock recorder for MockCache.
+type MockCacheMockRecorder struct {
+	mock *MockCache
+}
+
+// NewMockCache creates a new mock instance.
+func NewMockCache(ctrl *gomock.Controller) *MockCache {
+	mock := &MockCache{ctrl: ctrl}
+	mock.recorder = &MockCacheMockRecorder{mock}
+	return mock
+}
+
+// NewMockCacheWithDelegate creates a new mock instance that calls through to
+// delegate for methods that have no expected calls.
+func NewMockCacheWithDelegate(ctrl *gomock.Controller, delegate delegate.Cache) *MockCache {
+	mock := NewMockCache(ctrl)
+	mock.delegate = delegate
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCache) EXPECT() *MockCacheMockRecorder {
+	return m.recorder
+}
+
+// ISGOMOCK indicates that this struct is a gomock mock.
+func (m *MockCache) ISGOMOCK() struct{} {
+	return struct{}{}
+}
+
+// Get mocks base method.
+func (m *MockCache) Get(key string) (string, bool) {
+	m.ctrl.T.Helper()
+	if m.delegate != nil && !m.ctrl.HasExpectedCalls(m, "Get") {
+		return m.delegate.Get(key)
+	}
+	ret := m.ctrl.Call(m, "Get", key)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCacheMockRecorder) Get(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCache)(nil).Get), key)
+}
+
+// Keys mocks base method.
+func (m *MockCache) Keys(prefixes ...string) []string {
+	m.ctrl.T.Helper()
+	if m.delegate != nil && !m.ctrl.HasExpectedCalls(m, "Keys") {
+		return m.delegate.Keys(prefixes...)
+	}
+	varargs := []any{}
+	for _, a := range prefixes {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Keys", varargs...)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// Keys indicates an expected call of Keys.
+func (mr *MockCacheMockRecorder) Keys(prefixes ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Keys", reflect.TypeOf((*MockCache)(nil).Keys), prefixes...)
+}
+
+// Set mocks base method.
+func (m *MockCache) Set(key, value string) {
+	m.ctrl.T.Helper()
+	if m.delegate != nil && !m.ctrl.HasExpectedCalls(m, "Set") {
+		m.delegate.Set(key, value)
+		return
+	}
+	m.ctrl.Call(m, "Set", key, value)
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockCacheMockRecorder) Set(key, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockCache)(nil).Set), key, value)
+}
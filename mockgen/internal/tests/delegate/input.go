@@ -0,0 +1,10 @@
+package delegate
+
+//go:generate mockgen -package delegate -source=input.go -destination=mock.go -delegate
+//go:generate mockgen -source=input.go -destination=external/mock.go -delegate
+
+type Cache interface {
+	Get(key string) (string, bool)
+	Set(key, value string)
+	Keys(prefixes ...string) []string
+}
@@ -0,0 +1,79 @@
+package delegate
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+// mapCache is the real Cache the mocks delegate to.
+type mapCache map[string]string
+
+func (c mapCache) Get(key string) (string, bool) {
+	v, ok := c[key]
+	return v, ok
+}
+
+func (c mapCache) Set(key, value string) {
+	c[key] = value
+}
+
+func (c mapCache) Keys(prefixes ...string) []string {
+	var keys []string
+	for k := range c {
+		for _, p := range prefixes {
+			if strings.HasPrefix(k, p) {
+				keys = append(keys, k)
+				break
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestDelegateWithoutExpectations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	impl := mapCache{}
+	m := NewMockCacheWithDelegate(ctrl, impl)
+
+	m.Set("a1", "x")
+	m.Set("b1", "y")
+	if v, ok := m.Get("a1"); v != "x" || !ok {
+		t.Errorf("Get() = %q, %v, want %q, true", v, ok, "x")
+	}
+	if got, want := m.Keys("a", "b"), []string{"a1", "b1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys() = %v, want %v", got, want)
+	}
+	if len(impl) != 2 {
+		t.Errorf("the real cache has %d entries, want 2", len(impl))
+	}
+}
+
+func TestDelegateWithExpectations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	impl := mapCache{}
+	m := NewMockCacheWithDelegate(ctrl, impl)
+	m.EXPECT().Get("a1").Return("mocked", true)
+
+	m.Set("a1", "x")
+	if v, ok := m.Get("a1"); v != "mocked" || !ok {
+		t.Errorf("Get() = %q, %v, want %q, true", v, ok, "mocked")
+	}
+	if impl["a1"] != "x" {
+		t.Errorf("Set was not delegated to the real cache")
+	}
+}
+
+func TestWithoutDelegate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := NewMockCache(ctrl)
+	m.EXPECT().Keys("a").Return([]string{"a1"})
+
+	if got, want := m.Keys("a"), []string{"a1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,29 @@
+package embedded_stdlib_interface
+
+import (
+	"context"
+	"io"
+	"io/fs"
+)
+
+//go:generate mockgen -package embedded_stdlib_interface -source=input.go -destination=mock.go
+
+// Pinger is a local interface embedded by Service.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// Service embeds an interface from another package, which itself embeds
+// further interfaces, alongside a local one.
+type Service interface {
+	io.ReadWriteCloser
+	Pinger
+	Name() string
+}
+
+// Files embeds an interface from another package whose methods refer to
+// types of that package without qualification, and which transitively
+// embeds fs.FS.
+type Files interface {
+	fs.ReadDirFS
+}
@@ -0,0 +1,58 @@
+package embedded_stdlib_interface
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	_ Service       = (*MockService)(nil)
+	_ io.ReadWriter = (*MockService)(nil)
+	_ Files         = (*MockFiles)(nil)
+	_ fs.ReadDirFS  = (*MockFiles)(nil)
+)
+
+func TestPromotedMethods(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := NewMockService(ctrl)
+
+	gomock.InOrder(
+		m.EXPECT().Ping(gomock.Any()).Return(nil),
+		m.EXPECT().Write([]byte("hello")).Return(5, nil),
+		m.EXPECT().Read(gomock.Any()).Return(0, io.EOF),
+		m.EXPECT().Close().Return(nil),
+	)
+
+	var s Service = m
+	if err := s.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping() = %v, want nil", err)
+	}
+	if n, err := s.Write([]byte("hello")); n != 5 || err != nil {
+		t.Fatalf("Write() = %v, %v, want 5, nil", n, err)
+	}
+	if _, err := s.Read(make([]byte, 1)); err != io.EOF {
+		t.Fatalf("Read() error = %v, want %v", err, io.EOF)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close() = %v, want nil", err)
+	}
+}
+
+func TestTransitivelyEmbeddedMethods(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := NewMockFiles(ctrl)
+
+	m.EXPECT().Open("a.txt").Return(nil, fs.ErrNotExist)
+	m.EXPECT().ReadDir(".").Return([]fs.DirEntry{}, nil)
+
+	if _, err := fs.ReadFile(m, "a.txt"); err != fs.ErrNotExist {
+		t.Errorf("ReadFile() error = %v, want %v", err, fs.ErrNotExist)
+	}
+	if entries, err := fs.ReadDir(m, "."); len(entries) != 0 || err != nil {
+		t.Errorf("ReadDir() = %v, %v, want [], nil", entries, err)
+	}
+}
@@ -0,0 +1,11 @@
+package func_results
+
+//go:generate mockgen -typed -package func_results -source input.go -destination mock.go
+
+import "net/http"
+
+type Router interface {
+	Middleware() func(http.Handler) http.Handler
+	Chain(name string) func(func(string) error) (func() error, error)
+	Handle(pattern string, handler func(http.ResponseWriter, *http.Request)) error
+}
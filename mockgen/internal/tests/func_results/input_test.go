@@ -0,0 +1,64 @@
+package func_results
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestReturnFunc(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := NewMockRouter(ctrl)
+
+	var wrapped bool
+	m.EXPECT().Middleware().Return(func(h http.Handler) http.Handler {
+		wrapped = true
+		return h
+	})
+
+	m.Middleware()(http.NotFoundHandler())
+	if !wrapped {
+		t.Error("the returned middleware was not called")
+	}
+}
+
+func TestDoAndReturnNestedFunc(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := NewMockRouter(ctrl)
+
+	errBoom := errors.New("boom")
+	m.EXPECT().Chain("a").DoAndReturn(func(name string) func(func(string) error) (func() error, error) {
+		return func(next func(string) error) (func() error, error) {
+			return func() error { return next(name) }, nil
+		}
+	})
+
+	run, err := m.Chain("a")(func(string) error { return errBoom })
+	if err != nil {
+		t.Fatalf("Chain() returned error %v", err)
+	}
+	if err := run(); err != errBoom {
+		t.Errorf("run() = %v, want %v", err, errBoom)
+	}
+}
+
+func TestFuncParam(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := NewMockRouter(ctrl)
+
+	var served bool
+	m.EXPECT().Handle("/", gomock.Any()).DoAndReturn(func(_ string, handler func(http.ResponseWriter, *http.Request)) error {
+		handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		return nil
+	})
+
+	if err := m.Handle("/", func(http.ResponseWriter, *http.Request) { served = true }); err != nil {
+		t.Fatalf("Handle() = %v", err)
+	}
+	if !served {
+		t.Error("the handler passed to Handle was not called")
+	}
+}
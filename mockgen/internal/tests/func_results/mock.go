@@ -0,0 +1,159 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: input.go
+//
+// Generated by this command:
+//
+//	mockgen -typed -package func_results -source input.go -destination mock.go
+//
+
+// Package func_results is a generated GoMock package.
+package func_results
+
+import (
+	http "net/http"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRouter is a mock of Router interface.
+type MockRouter struct {
+	ctrl     *gomock.Controller
+	recorder *MockRouterMockRecorder
+}
+
+// MockRouterMockRecorder is the mock recorder for MockRouter.
+type MockRouterMockRecorder struct {
+	mock *MockRouter
+}
+
+// NewMockRouter creates a new mock instance.
+func NewMockRouter(ctrl *gomock.Controller) *MockRouter {
+	mock := &MockRouter{ctrl: ctrl}
+	mock.recorder = &MockRouterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRouter) EXPECT() *MockRouterMockRecorder {
+	return m.recorder
+}
+
+// ISGOMOCK indicates that this struct is a gomock mock.
+func (m *MockRouter) ISGOMOCK() struct{} {
+	return struct{}{}
+}
+
+// Chain mocks base method.
+func (m *MockRouter) Chain(name string) func(func(string) error) (func() error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Chain", name)
+	ret0, _ := ret[0].(func(func(string) error) (func() error, error))
+	return ret0
+}
+
+// Chain indicates an expected call of Chain.
+func (mr *MockRouterMockRecorder) Chain(name any) *MockRouterChainCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Chain", reflect.TypeOf((*MockRouter)(nil).Chain), name)
+	return &MockRouterChainCall{Call: call}
+}
+
+// MockRouterChainCall wrap *gomock.Call
+type MockRouterChainCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRouterChainCall) Return(arg0 func(func(string) error) (func() error, error)) *MockRouterChainCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRouterChainCall) Do(f func(string) func(func(string) error) (func() error, error)) *MockRouterChainCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRouterChainCall) DoAndReturn(f func(string) func(func(string) error) (func() error, error)) *MockRouterChainCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Handle mocks base method.
+func (m *MockRouter) Handle(pattern string, handler func(http.ResponseWriter, *http.Request)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Handle", pattern, handler)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Handle indicates an expected call of Handle.
+func (mr *MockRouterMockRecorder) Handle(pattern, handler any) *MockRouterHandleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Handle", reflect.TypeOf((*MockRouter)(nil).Handle), pattern, handler)
+	return &MockRouterHandleCall{Call: call}
+}
+
+// MockRouterHandleCall wrap *gomock.Call
+type MockRouterHandleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRouterHandleCall) Return(arg0 error) *MockRouterHandleCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRouterHandleCall) Do(f func(string, func(http.ResponseWriter, *http.Request)) error) *MockRouterHandleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRouterHandleCall) DoAndReturn(f func(string, func(http.ResponseWriter, *http.Request)) error) *MockRouterHandleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Middleware mocks base method.
+func (m *MockRouter) Middleware() func(http.Handler) http.Handler {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Middleware")
+	ret0, _ := ret[0].(func(http.Handler) http.Handler)
+	return ret0
+}
+
+// Middleware indicates an expected call of Middleware.
+func (mr *MockRouterMockRecorder) Middleware() *MockRouterMiddlewareCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Middleware", reflect.TypeOf((*MockRouter)(nil).Middleware))
+	return &MockRouterMiddlewareCall{Call: call}
+}
+
+// MockRouterMiddlewareCall wrap *gomock.Call
+type MockRouterMiddlewareCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockRouterMiddlewareCall) Return(arg0 func(http.Handler) http.Handler) *MockRouterMiddlewareCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockRouterMiddlewareCall) Do(f func() func(http.Handler) http.Handler) *MockRouterMiddlewareCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockRouterMiddlewareCall) DoAndReturn(f func() func(http.Handler) http.Handler) *MockRouterMiddlewareCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,17 @@
+package func_types
+
+import "context"
+
+//go:generate mockgen -package func_types -source=input.go -destination=mock.go -func_types=Handler,Logf,Resolver
+
+// Handler handles a single request.
+type Handler func(ctx context.Context, id string) error
+
+// Logf formats and records a message.
+type Logf func(format string, args ...any)
+
+// Resolver looks up an address.
+type Resolver func(host string) (addr string, port int, err error)
+
+// Unrelated is not listed in -func_types and gets no mock.
+type Unrelated func()
@@ -0,0 +1,42 @@
+package func_types
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestMockHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := NewMockHandler(ctrl)
+	errNotFound := errors.New("not found")
+	m.EXPECT().Call(gomock.Any(), "42").Return(errNotFound)
+
+	var h Handler = m.Call
+	if err := h(context.Background(), "42"); !errors.Is(err, errNotFound) {
+		t.Errorf("got %v, want %v", err, errNotFound)
+	}
+}
+
+func TestMockLogf(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := NewMockLogf(ctrl)
+	m.EXPECT().Call("%s=%d", "a", 1)
+
+	var logf Logf = m.Call
+	logf("%s=%d", "a", 1)
+}
+
+func TestMockResolver(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := NewMockResolver(ctrl)
+	m.EXPECT().Call("example.com").Return("93.184.216.34", 443, nil)
+
+	var resolve Resolver = m.Call
+	addr, port, err := resolve("example.com")
+	if addr != "93.184.216.34" || port != 443 || err != nil {
+		t.Errorf("got (%q, %d, %v), want (%q, %d, nil)", addr, port, err, "93.184.216.34", 443)
+	}
+}
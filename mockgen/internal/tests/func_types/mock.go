@@ -0,0 +1,151 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: input.go
+//
+// Generated by this command:
+//
+//	mockgen -package func_types -source=input.go -destination=mock.go -func_types=Handler,Logf,Resolver
+//
+
+// Package func_types is a generated GoMock package.
+package func_types
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHandler is a mock of Handler function type. Use its Call method
+// value wherever a Handler is expected.
+type MockHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockHandlerMockRecorder
+}
+
+// MockHandlerMockRecorder is the mock recorder for MockHandler.
+type MockHandlerMockRecorder struct {
+	mock *MockHandler
+}
+
+// NewMockHandler creates a new mock instance.
+func NewMockHandler(ctrl *gomock.Controller) *MockHandler {
+	mock := &MockHandler{ctrl: ctrl}
+	mock.recorder = &MockHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHandler) EXPECT() *MockHandlerMockRecorder {
+	return m.recorder
+}
+
+// ISGOMOCK indicates that this struct is a gomock mock.
+func (m *MockHandler) ISGOMOCK() struct{} {
+	return struct{}{}
+}
+
+// Call mocks base method.
+func (m *MockHandler) Call(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Call", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Call indicates an expected call of Call.
+func (mr *MockHandlerMockRecorder) Call(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Call", reflect.TypeOf((*MockHandler)(nil).Call), ctx, id)
+}
+
+// MockLogf is a mock of Logf function type. Use its Call method
+// value wherever a Logf is expected.
+type MockLogf struct {
+	ctrl     *gomock.Controller
+	recorder *MockLogfMockRecorder
+}
+
+// MockLogfMockRecorder is the mock recorder for MockLogf.
+type MockLogfMockRecorder struct {
+	mock *MockLogf
+}
+
+// NewMockLogf creates a new mock instance.
+func NewMockLogf(ctrl *gomock.Controller) *MockLogf {
+	mock := &MockLogf{ctrl: ctrl}
+	mock.recorder = &MockLogfMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLogf) EXPECT() *MockLogfMockRecorder {
+	return m.recorder
+}
+
+// ISGOMOCK indicates that this struct is a gomock mock.
+func (m *MockLogf) ISGOMOCK() struct{} {
+	return struct{}{}
+}
+
+// Call mocks base method.
+func (m *MockLogf) Call(format string, args ...any) {
+	m.ctrl.T.Helper()
+	varargs := []any{format}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Call", varargs...)
+}
+
+// Call indicates an expected call of Call.
+func (mr *MockLogfMockRecorder) Call(format any, args ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{format}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Call", reflect.TypeOf((*MockLogf)(nil).Call), varargs...)
+}
+
+// MockResolver is a mock of Resolver function type. Use its Call method
+// value wherever a Resolver is expected.
+type MockResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockResolverMockRecorder
+}
+
+// MockResolverMockRecorder is the mock recorder for MockResolver.
+type MockResolverMockRecorder struct {
+	mock *MockResolver
+}
+
+// NewMockResolver creates a new mock instance.
+func NewMockResolver(ctrl *gomock.Controller) *MockResolver {
+	mock := &MockResolver{ctrl: ctrl}
+	mock.recorder = &MockResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResolver) EXPECT() *MockResolverMockRecorder {
+	return m.recorder
+}
+
+// ISGOMOCK indicates that this struct is a gomock mock.
+func (m *MockResolver) ISGOMOCK() struct{} {
+	return struct{}{}
+}
+
+// Call mocks base method.
+func (m *MockResolver) Call(host string) (string, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Call", host)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Call indicates an expected call of Call.
+func (mr *MockResolverMockRecorder) Call(host any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Call", reflect.TypeOf((*MockResolver)(nil).Call), host)
+}
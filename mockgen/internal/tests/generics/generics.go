@@ -65,3 +65,18 @@ type Water[R any, C UnsignedInteger] interface {
 type UnsignedInteger interface {
 	~uint | ~uint32 | ~uint64
 }
+
+type Cache[K comparable] interface {
+	Get(key K) (any, bool)
+	Keys() []K
+}
+
+type Repository[K comparable, V any, N Number] interface {
+	Find(id K) (V, error)
+	Save(id K, value V) error
+	Count() N
+}
+
+type Number interface {
+	~int | ~int64 | ~float64
+}
@@ -7,4 +7,6 @@ require (
 	golang.org/x/exp v0.0.0-20220428152302-39d4317da171
 )
 
+require github.com/google/go-cmp v0.6.0 // indirect
+
 replace go.uber.org/mock => ../../../..
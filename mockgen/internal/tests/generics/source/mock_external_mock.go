@@ -1,5 +1,10 @@
 // Code generated by MockGen. DO NOT EDIT.
 // Source: external.go
+//
+// Generated by this command:
+//
+//	mockgen --source=external.go --destination=source/mock_external_mock.go --package source
+//
 
 // Package source is a generated GoMock package.
 package source
@@ -37,6 +42,11 @@ func (m *MockExternalConstraint[I, F]) EXPECT() *MockExternalConstraintMockRecor
 	return m.recorder
 }
 
+// ISGOMOCK indicates that this struct is a gomock mock.
+func (m *MockExternalConstraint[I, F]) ISGOMOCK() struct{} {
+	return struct{}{}
+}
+
 // Eight mocks base method.
 func (m *MockExternalConstraint[I, F]) Eight(arg0 F) other.Two[I, F] {
 	m.ctrl.T.Helper()
@@ -242,6 +252,11 @@ func (m *MockEmbeddingIface[T, R]) EXPECT() *MockEmbeddingIfaceMockRecorder[T, R
 	return m.recorder
 }
 
+// ISGOMOCK indicates that this struct is a gomock mock.
+func (m *MockEmbeddingIface[T, R]) ISGOMOCK() struct{} {
+	return struct{}{}
+}
+
 // Eight mocks base method.
 func (m *MockEmbeddingIface[T, R]) Eight(arg0 R) other.Two[T, R] {
 	m.ctrl.T.Helper()
@@ -546,6 +561,11 @@ func (m *MockGenerator[T]) EXPECT() *MockGeneratorMockRecorder[T] {
 	return m.recorder
 }
 
+// ISGOMOCK indicates that this struct is a gomock mock.
+func (m *MockGenerator[T]) ISGOMOCK() struct{} {
+	return struct{}{}
+}
+
 // Generate mocks base method.
 func (m *MockGenerator[T]) Generate() T {
 	m.ctrl.T.Helper()
@@ -583,6 +603,11 @@ func (m *MockGroup[T]) EXPECT() *MockGroupMockRecorder[T] {
 	return m.recorder
 }
 
+// ISGOMOCK indicates that this struct is a gomock mock.
+func (m *MockGroup[T]) ISGOMOCK() struct{} {
+	return struct{}{}
+}
+
 // Join mocks base method.
 func (m *MockGroup[T]) Join(ctx context.Context) []T {
 	m.ctrl.T.Helper()
@@ -41,6 +41,11 @@ func (m *MockBar[T, R]) EXPECT() *MockBarMockRecorder[T, R] {
 	return m.recorder
 }
 
+// ISGOMOCK indicates that this struct is a gomock mock.
+func (m *MockBar[T, R]) ISGOMOCK() struct{} {
+	return struct{}{}
+}
+
 // Eight mocks base method.
 func (m *MockBar[T, R]) Eight(arg0 T) other.Two[T, R] {
 	m.ctrl.T.Helper()
@@ -334,6 +339,11 @@ func (m *MockUniverse[T]) EXPECT() *MockUniverseMockRecorder[T] {
 	return m.recorder
 }
 
+// ISGOMOCK indicates that this struct is a gomock mock.
+func (m *MockUniverse[T]) ISGOMOCK() struct{} {
+	return struct{}{}
+}
+
 // Water mocks base method.
 func (m *MockUniverse[T]) Water(arg0 T) []T {
 	m.ctrl.T.Helper()
@@ -371,6 +381,11 @@ func (m *MockMilkyWay[R]) EXPECT() *MockMilkyWayMockRecorder[R] {
 	return m.recorder
 }
 
+// ISGOMOCK indicates that this struct is a gomock mock.
+func (m *MockMilkyWay[R]) ISGOMOCK() struct{} {
+	return struct{}{}
+}
+
 // Water mocks base method.
 func (m *MockMilkyWay[R]) Water(arg0 R) []R {
 	m.ctrl.T.Helper()
@@ -408,6 +423,11 @@ func (m *MockSolarSystem[T]) EXPECT() *MockSolarSystemMockRecorder[T] {
 	return m.recorder
 }
 
+// ISGOMOCK indicates that this struct is a gomock mock.
+func (m *MockSolarSystem[T]) ISGOMOCK() struct{} {
+	return struct{}{}
+}
+
 // Water mocks base method.
 func (m *MockSolarSystem[T]) Water(arg0 T) []T {
 	m.ctrl.T.Helper()
@@ -445,6 +465,11 @@ func (m *MockEarth[R]) EXPECT() *MockEarthMockRecorder[R] {
 	return m.recorder
 }
 
+// ISGOMOCK indicates that this struct is a gomock mock.
+func (m *MockEarth[R]) ISGOMOCK() struct{} {
+	return struct{}{}
+}
+
 // Water mocks base method.
 func (m *MockEarth[R]) Water(arg0 R) []R {
 	m.ctrl.T.Helper()
@@ -482,6 +507,11 @@ func (m *MockWater[R, C]) EXPECT() *MockWaterMockRecorder[R, C] {
 	return m.recorder
 }
 
+// ISGOMOCK indicates that this struct is a gomock mock.
+func (m *MockWater[R, C]) ISGOMOCK() struct{} {
+	return struct{}{}
+}
+
 // Fish mocks base method.
 func (m *MockWater[R, C]) Fish(arg0 R) []C {
 	m.ctrl.T.Helper()
@@ -495,3 +525,131 @@ func (mr *MockWaterMockRecorder[R, C]) Fish(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fish", reflect.TypeOf((*MockWater[R, C])(nil).Fish), arg0)
 }
+
+// MockCache is a mock of Cache interface.
+type MockCache[K comparable] struct {
+	ctrl     *gomock.Controller
+	recorder *MockCacheMockRecorder[K]
+}
+
+// MockCacheMockRecorder is the mock recorder for MockCache.
+type MockCacheMockRecorder[K comparable] struct {
+	mock *MockCache[K]
+}
+
+// NewMockCache creates a new mock instance.
+func NewMockCache[K comparable](ctrl *gomock.Controller) *MockCache[K] {
+	mock := &MockCache[K]{ctrl: ctrl}
+	mock.recorder = &MockCacheMockRecorder[K]{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCache[K]) EXPECT() *MockCacheMockRecorder[K] {
+	return m.recorder
+}
+
+// ISGOMOCK indicates that this struct is a gomock mock.
+func (m *MockCache[K]) ISGOMOCK() struct{} {
+	return struct{}{}
+}
+
+// Get mocks base method.
+func (m *MockCache[K]) Get(key K) (any, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", key)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCacheMockRecorder[K]) Get(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCache[K])(nil).Get), key)
+}
+
+// Keys mocks base method.
+func (m *MockCache[K]) Keys() []K {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Keys")
+	ret0, _ := ret[0].([]K)
+	return ret0
+}
+
+// Keys indicates an expected call of Keys.
+func (mr *MockCacheMockRecorder[K]) Keys() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Keys", reflect.TypeOf((*MockCache[K])(nil).Keys))
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository[K comparable, V any, N generics.Number] struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder[K, V, N]
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder[K comparable, V any, N generics.Number] struct {
+	mock *MockRepository[K, V, N]
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository[K comparable, V any, N generics.Number](ctrl *gomock.Controller) *MockRepository[K, V, N] {
+	mock := &MockRepository[K, V, N]{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder[K, V, N]{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository[K, V, N]) EXPECT() *MockRepositoryMockRecorder[K, V, N] {
+	return m.recorder
+}
+
+// ISGOMOCK indicates that this struct is a gomock mock.
+func (m *MockRepository[K, V, N]) ISGOMOCK() struct{} {
+	return struct{}{}
+}
+
+// Count mocks base method.
+func (m *MockRepository[K, V, N]) Count() N {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count")
+	ret0, _ := ret[0].(N)
+	return ret0
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockRepositoryMockRecorder[K, V, N]) Count() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockRepository[K, V, N])(nil).Count))
+}
+
+// Find mocks base method.
+func (m *MockRepository[K, V, N]) Find(id K) (V, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", id)
+	ret0, _ := ret[0].(V)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockRepositoryMockRecorder[K, V, N]) Find(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockRepository[K, V, N])(nil).Find), id)
+}
+
+// Save mocks base method.
+func (m *MockRepository[K, V, N]) Save(id K, value V) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", id, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockRepositoryMockRecorder[K, V, N]) Save(id, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockRepository[K, V, N])(nil).Save), id, value)
+}
@@ -0,0 +1,41 @@
+package source
+
+import (
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestMockCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	m := NewMockCache[string](ctrl)
+	m.EXPECT().Get("foo").Return(1, true)
+	m.EXPECT().Keys().Return([]string{"foo"})
+	if v, ok := m.Get("foo"); v != 1 || !ok {
+		t.Errorf("Get() = %v, %v, want %v, %v", v, ok, 1, true)
+	}
+	if v := m.Keys(); len(v) != 1 || v[0] != "foo" {
+		t.Errorf("Keys() = %v, want %v", v, []string{"foo"})
+	}
+}
+
+type userID int
+
+func TestMockRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	m := NewMockRepository[userID, string, float64](ctrl)
+	m.EXPECT().Save(userID(1), "alice").Return(nil)
+	m.EXPECT().Find(userID(1)).Return("alice", nil)
+	m.EXPECT().Count().Return(1.0)
+	if err := m.Save(1, "alice"); err != nil {
+		t.Errorf("Save() = %v, want %v", err, nil)
+	}
+	if v, err := m.Find(1); v != "alice" || err != nil {
+		t.Errorf("Find() = %v, %v, want %v, %v", v, err, "alice", nil)
+	}
+	if v := m.Count(); v != 1.0 {
+		t.Errorf("Count() = %v, want %v", v, 1.0)
+	}
+}
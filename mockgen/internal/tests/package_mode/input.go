@@ -0,0 +1,13 @@
+package package_mode
+
+//go:generate mockgen -package_mode internal -source input.go -destination mock_internal.go
+//go:generate mockgen -package_mode external -source input.go -destination external/mock.go
+
+type Record struct {
+	Key, Value string
+}
+
+type Store interface {
+	Get(key string) (*Record, error)
+	Put(r Record) error
+}
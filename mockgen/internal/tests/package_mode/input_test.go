@@ -0,0 +1,33 @@
+package package_mode_test
+
+import (
+	"testing"
+
+	"go.uber.org/mock/gomock"
+	"go.uber.org/mock/mockgen/internal/tests/package_mode"
+	mock_package_mode "go.uber.org/mock/mockgen/internal/tests/package_mode/external"
+)
+
+func TestInternalMock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := package_mode.NewMockStore(ctrl)
+	want := &package_mode.Record{Key: "a", Value: "b"}
+	m.EXPECT().Get("a").Return(want, nil)
+
+	var s package_mode.Store = m
+	if got, err := s.Get("a"); got != want || err != nil {
+		t.Errorf("Get() = %v, %v, want %v, nil", got, err, want)
+	}
+}
+
+func TestExternalMock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mock_package_mode.NewMockStore(ctrl)
+	want := &package_mode.Record{Key: "a", Value: "b"}
+	m.EXPECT().Get("a").Return(want, nil)
+
+	var s package_mode.Store = m
+	if got, err := s.Get("a"); got != want || err != nil {
+		t.Errorf("Get() = %v, %v, want %v, nil", got, err, want)
+	}
+}
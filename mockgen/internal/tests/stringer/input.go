@@ -0,0 +1,12 @@
+package stringer
+
+//go:generate mockgen -package stringer -source=input.go -destination=mock.go -stringer
+
+type Store interface {
+	Get(key string) (string, error)
+}
+
+type Named interface {
+	Name() string
+	String() string
+}
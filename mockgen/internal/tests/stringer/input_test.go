@@ -0,0 +1,38 @@
+package stringer
+
+import (
+	"fmt"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestGeneratedString(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := NewMockStore(ctrl)
+
+	var s fmt.Stringer = m
+	if got, want := s.String(), "*MockStore"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got, want := fmt.Sprint(m), "*MockStore"; got != want {
+		t.Errorf("fmt.Sprint() = %q, want %q", got, want)
+	}
+}
+
+func TestGeneratedStringDoesNotUseController(t *testing.T) {
+	// A mock without a controller would panic if String used it.
+	if got, want := new(MockStore).String(), "*MockStore"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestMockedString(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := NewMockNamed(ctrl)
+	m.EXPECT().String().Return("named")
+
+	if got, want := m.String(), "named"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
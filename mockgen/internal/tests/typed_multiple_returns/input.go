@@ -0,0 +1,7 @@
+package typed_multiple_returns
+
+//go:generate mockgen -package typed_multiple_returns -source=input.go -destination=mock.go -typed
+type Store interface {
+	Lookup(key string) (value int, found bool, err error)
+	Range(from, to string, limit int) ([]string, error)
+}
@@ -0,0 +1,37 @@
+package typed_multiple_returns
+
+import (
+	"errors"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestTypedReturnMultipleValues(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	m := NewMockStore(ctrl)
+	m.EXPECT().Lookup("a").Return(1, true, nil)
+	m.EXPECT().Range("a", "z", 2).Return([]string{"a", "b"}, nil)
+
+	if v, found, err := m.Lookup("a"); v != 1 || !found || err != nil {
+		t.Errorf("Lookup() = %v, %v, %v, want %v, %v, %v", v, found, err, 1, true, nil)
+	}
+	if keys, err := m.Range("a", "z", 2); len(keys) != 2 || err != nil {
+		t.Errorf("Range() = %v, %v, want %v, %v", keys, err, []string{"a", "b"}, nil)
+	}
+}
+
+func TestTypedDoAndReturnMultipleValues(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	errNotFound := errors.New("not found")
+	m := NewMockStore(ctrl)
+	m.EXPECT().Lookup(gomock.Any()).DoAndReturn(func(key string) (int, bool, error) {
+		return 0, false, errNotFound
+	})
+
+	if _, found, err := m.Lookup("missing"); found || err != errNotFound {
+		t.Errorf("Lookup() = _, %v, %v, want _, %v, %v", found, err, false, errNotFound)
+	}
+}
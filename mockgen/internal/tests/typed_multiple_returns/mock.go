@@ -0,0 +1,123 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: input.go
+//
+// Generated by this command:
+//
+//	mockgen -package typed_multiple_returns -source=input.go -destination=mock.go -typed
+//
+
+// Package typed_multiple_returns is a generated GoMock package.
+package typed_multiple_returns
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStore is a mock of Store interface.
+type MockStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreMockRecorder
+}
+
+// MockStoreMockRecorder is the mock recorder for MockStore.
+type MockStoreMockRecorder struct {
+	mock *MockStore
+}
+
+// NewMockStore creates a new mock instance.
+func NewMockStore(ctrl *gomock.Controller) *MockStore {
+	mock := &MockStore{ctrl: ctrl}
+	mock.recorder = &MockStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStore) EXPECT() *MockStoreMockRecorder {
+	return m.recorder
+}
+
+// ISGOMOCK indicates that this struct is a gomock mock.
+func (m *MockStore) ISGOMOCK() struct{} {
+	return struct{}{}
+}
+
+// Lookup mocks base method.
+func (m *MockStore) Lookup(key string) (int, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lookup", key)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Lookup indicates an expected call of Lookup.
+func (mr *MockStoreMockRecorder) Lookup(key any) *MockStoreLookupCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lookup", reflect.TypeOf((*MockStore)(nil).Lookup), key)
+	return &MockStoreLookupCall{Call: call}
+}
+
+// MockStoreLookupCall wrap *gomock.Call
+type MockStoreLookupCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockStoreLookupCall) Return(value int, found bool, err error) *MockStoreLookupCall {
+	c.Call = c.Call.Return(value, found, err)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockStoreLookupCall) Do(f func(string) (int, bool, error)) *MockStoreLookupCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockStoreLookupCall) DoAndReturn(f func(string) (int, bool, error)) *MockStoreLookupCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Range mocks base method.
+func (m *MockStore) Range(from, to string, limit int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Range", from, to, limit)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Range indicates an expected call of Range.
+func (mr *MockStoreMockRecorder) Range(from, to, limit any) *MockStoreRangeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Range", reflect.TypeOf((*MockStore)(nil).Range), from, to, limit)
+	return &MockStoreRangeCall{Call: call}
+}
+
+// MockStoreRangeCall wrap *gomock.Call
+type MockStoreRangeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockStoreRangeCall) Return(arg0 []string, arg1 error) *MockStoreRangeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockStoreRangeCall) Do(f func(string, string, int) ([]string, error)) *MockStoreRangeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockStoreRangeCall) DoAndReturn(f func(string, string, int) ([]string, error)) *MockStoreRangeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -45,6 +45,14 @@ import (
 
 const (
 	gomockImportPath = "go.uber.org/mock/gomock"
+
+	methodOrderAlphabetical = "alphabetical"
+	methodOrderSource       = "source"
+
+	defaultReturnError = "error"
+
+	packageModeExternal = "external"
+	packageModeInternal = "internal"
 )
 
 var (
@@ -68,6 +76,12 @@ var (
 	imports                = flag.String("imports", "", "(source mode) Comma-separated name=path pairs of explicit imports to use.")
 	auxFiles               = flag.String("aux_files", "", "(source mode) Comma-separated pkg=path pairs of auxiliary Go source files.")
 	excludeInterfaces      = flag.String("exclude_interfaces", "", "Comma-separated names of interfaces to be excluded")
+	methodOrder            = flag.String("method_order", methodOrderAlphabetical, "Order of the generated mock methods: 'alphabetical' or 'source' (declaration order of the interface).")
+	defaultReturn          = flag.String("default_return", "", "If set to 'error', mocked methods whose last result is an error return gomock.ErrNotMocked instead of failing the test when no call of them was expected.")
+	packageMode            = flag.String("package_mode", packageModeExternal, "Placement of the generated code: 'external' for a separate package, named by -package, or 'internal' for the package of the input, with its types left unqualified.")
+	delegate               = flag.Bool("delegate", false, "Generate mocks that call through to a real implementation, passed to NewMockXWithDelegate, for methods that have no expected calls.")
+	stringer               = flag.Bool("stringer", false, "Generate a String method returning the mock type name, so that mocks implement fmt.Stringer. Skipped for interfaces that have a String method.")
+	funcTypes              = flag.String("func_types", "", "(source mode) Comma-separated names of function types to generate mocks for.")
 
 	debugParser = flag.Bool("debug_parser", false, "Print out parser results only.")
 	showVersion = flag.Bool("version", false, "Print version.")
@@ -115,11 +129,21 @@ func main() {
 		return
 	}
 
+	switch *packageMode {
+	case packageModeExternal, packageModeInternal:
+	default:
+		log.Fatalf("Unknown -package_mode %q, must be %q or %q", *packageMode, packageModeExternal, packageModeInternal)
+	}
+
 	outputPackageName := *packageOut
 	if outputPackageName == "" {
-		// pkg.Name in reflect mode is the base name of the import path,
-		// which might have characters that are illegal to have in package names.
-		outputPackageName = "mock_" + sanitize(pkg.Name)
+		if *packageMode == packageModeInternal {
+			outputPackageName = pkg.Name
+		} else {
+			// pkg.Name in reflect mode is the base name of the import path,
+			// which might have characters that are illegal to have in package names.
+			outputPackageName = "mock_" + sanitize(pkg.Name)
+		}
 	}
 
 	// outputPackagePath represents the fully qualified name of the package of
@@ -129,6 +153,12 @@ func main() {
 	// "package.X" since "package" is this package). This can happen if the mock
 	// is output into an already existing package.
 	outputPackagePath := *selfPackage
+	if outputPackagePath == "" && *packageMode == packageModeInternal {
+		if pkg.PkgPath == "" {
+			log.Fatal("Unable to determine the import path of the input package for -package_mode=internal; set -self_package")
+		}
+		outputPackagePath = pkg.PkgPath
+	}
 	if outputPackagePath == "" && *destination != "" {
 		dstPath, err := filepath.Abs(filepath.Dir(*destination))
 		if err == nil {
@@ -151,6 +181,21 @@ func main() {
 		g.srcInterfaces = flag.Arg(1)
 	}
 	g.destination = *destination
+	switch *methodOrder {
+	case methodOrderAlphabetical, methodOrderSource:
+		g.methodOrder = *methodOrder
+	default:
+		log.Fatalf("Unknown -method_order %q, must be %q or %q", *methodOrder, methodOrderAlphabetical, methodOrderSource)
+	}
+	switch *defaultReturn {
+	case "", defaultReturnError:
+		g.defaultReturn = *defaultReturn
+	default:
+		log.Fatalf("Unknown -default_return %q, must be %q", *defaultReturn, defaultReturnError)
+	}
+
+	g.delegate = *delegate
+	g.stringer = *stringer
 
 	if *mockNames != "" {
 		g.mockNames = parseMockNames(*mockNames)
@@ -251,6 +296,11 @@ type generator struct {
 	destination               string            // may be empty
 	srcPackage, srcInterfaces string            // may be empty
 	copyrightHeader           string
+	methodOrder               string // methodOrderAlphabetical if empty
+	defaultReturn             string // may be empty
+	delegate                  bool
+	stringer                  bool
+	srcPackagePath            string // import path of the mocked interfaces
 
 	packageMap map[string]string // map from import path to package name
 }
@@ -330,6 +380,14 @@ func (g *generator) Generate(pkg *model.Package, outputPkgName string, outputPac
 	// Get all required imports, and generate unique names for them all.
 	im := pkg.Imports()
 	im[gomockImportPath] = true
+	if g.delegate {
+		// Delegating mocks refer to the mocked interfaces themselves.
+		if pkg.PkgPath == "" {
+			return errors.New("unable to determine the import path of the input package for -delegate")
+		}
+		im[pkg.PkgPath] = true
+	}
+	g.srcPackagePath = pkg.PkgPath
 
 	// Only import reflect if it's used. We only use reflect in mocked methods
 	// so only import if any of the mocked interfaces have methods.
@@ -406,8 +464,9 @@ func (g *generator) Generate(pkg *model.Package, outputPkgName string, outputPac
 	g.p("")
 	g.p("import (")
 	g.in()
-	for pkgPath, pkgName := range g.packageMap {
-		if pkgPath == outputPackagePath {
+	for _, pkgPath := range sortedPaths {
+		pkgName, ok := g.packageMap[pkgPath]
+		if !ok || pkgPath == outputPackagePath {
 			continue
 		}
 		g.p("%v %q", pkgName, pkgPath)
@@ -470,11 +529,22 @@ func (g *generator) GenerateMockInterface(intf *model.Interface, outputPackagePa
 	longTp, shortTp := g.formattedTypeParams(intf, outputPackagePath)
 
 	g.p("")
-	g.p("// %v is a mock of %v interface.", mockType, intf.Name)
+	if intf.FuncType {
+		g.p("// %v is a mock of %v function type. Use its Call method", mockType, intf.Name)
+		g.p("// value wherever a %v is expected.", intf.Name)
+	} else {
+		g.p("// %v is a mock of %v interface.", mockType, intf.Name)
+	}
 	g.p("type %v%v struct {", mockType, longTp)
 	g.in()
 	g.p("ctrl     *gomock.Controller")
 	g.p("recorder *%vMockRecorder%v", mockType, shortTp)
+	delegate := g.delegate && !intf.FuncType
+	var intfType string
+	if delegate {
+		intfType = (&model.NamedType{Package: g.srcPackagePath, Type: intf.Name}).String(g.packageMap, outputPackagePath) + shortTp
+		g.p("delegate %v", intfType)
+	}
 	g.out()
 	g.p("}")
 	g.p("")
@@ -497,6 +567,19 @@ func (g *generator) GenerateMockInterface(intf *model.Interface, outputPackagePa
 	g.p("}")
 	g.p("")
 
+	if delegate {
+		g.p("// New%vWithDelegate creates a new mock instance that calls through to", mockType)
+		g.p("// delegate for methods that have no expected calls.")
+		g.p("func New%vWithDelegate%v(ctrl *gomock.Controller, delegate %v) *%v%v {", mockType, longTp, intfType, mockType, shortTp)
+		g.in()
+		g.p("mock := New%v%v(ctrl)", mockType, shortTp)
+		g.p("mock.delegate = delegate")
+		g.p("return mock")
+		g.out()
+		g.p("}")
+		g.p("")
+	}
+
 	// XXX: possible name collision here if someone has EXPECT in their interface.
 	g.p("// EXPECT returns an object that allows the caller to indicate expected use.")
 	g.p("func (m *%v%v) EXPECT() *%vMockRecorder%v {", mockType, shortTp, mockType, shortTp)
@@ -513,22 +596,46 @@ func (g *generator) GenerateMockInterface(intf *model.Interface, outputPackagePa
 	g.out()
 	g.p("}")
 
-	g.GenerateMockMethods(mockType, intf, outputPackagePath, longTp, shortTp, *typed)
+	if g.stringer && !hasMethod(intf, "String") {
+		// The method must not use the controller: it may be called to format
+		// a failure message while the controller is locked.
+		g.p("")
+		g.p("// String returns the name of the mock type.")
+		g.p("func (m *%v%v) String() string {", mockType, shortTp)
+		g.in()
+		g.p("return %q", "*"+mockType)
+		g.out()
+		g.p("}")
+	}
+
+	g.GenerateMockMethods(mockType, intf, outputPackagePath, longTp, shortTp, *typed, delegate)
 
 	return nil
 }
 
+// hasMethod reports whether intf has a method named name.
+func hasMethod(intf *model.Interface, name string) bool {
+	for _, m := range intf.Methods {
+		if m.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 type byMethodName []*model.Method
 
 func (b byMethodName) Len() int           { return len(b) }
 func (b byMethodName) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
 func (b byMethodName) Less(i, j int) bool { return b[i].Name < b[j].Name }
 
-func (g *generator) GenerateMockMethods(mockType string, intf *model.Interface, pkgOverride, longTp, shortTp string, typed bool) {
-	sort.Sort(byMethodName(intf.Methods))
+func (g *generator) GenerateMockMethods(mockType string, intf *model.Interface, pkgOverride, longTp, shortTp string, typed, delegate bool) {
+	if g.methodOrder != methodOrderSource {
+		sort.Sort(byMethodName(intf.Methods))
+	}
 	for _, m := range intf.Methods {
 		g.p("")
-		_ = g.GenerateMockMethod(mockType, m, pkgOverride, shortTp)
+		_ = g.GenerateMockMethod(mockType, m, pkgOverride, shortTp, delegate)
 		g.p("")
 		_ = g.GenerateMockRecorderMethod(intf, m, shortTp, typed)
 		if typed {
@@ -553,7 +660,9 @@ func makeArgString(argNames, argTypes []string) string {
 
 // GenerateMockMethod generates a mock method implementation.
 // If non-empty, pkgOverride is the package in which unqualified types reside.
-func (g *generator) GenerateMockMethod(mockType string, m *model.Method, pkgOverride, shortTp string) error {
+// If delegate is set, the method calls through to the mock's delegate, if
+// any, when it has no expected calls.
+func (g *generator) GenerateMockMethod(mockType string, m *model.Method, pkgOverride, shortTp string, delegate bool) error {
 	argNames := g.getArgNames(m, true /* in */)
 	argTypes := g.getArgTypes(m, pkgOverride, true /* in */)
 	argString := makeArgString(argNames, argTypes)
@@ -578,6 +687,23 @@ func (g *generator) GenerateMockMethod(mockType string, m *model.Method, pkgOver
 	g.in()
 	g.p("%s.ctrl.T.Helper()", idRecv)
 
+	if delegate {
+		delegateArgs := strings.Join(argNames, ", ")
+		if m.Variadic != nil {
+			delegateArgs += "..."
+		}
+		g.p("if %v.delegate != nil && !%v.ctrl.HasExpectedCalls(%v, %q) {", idRecv, idRecv, idRecv, m.Name)
+		g.in()
+		if len(m.Out) == 0 {
+			g.p("%v.delegate.%v(%v)", idRecv, m.Name, delegateArgs)
+			g.p("return")
+		} else {
+			g.p("return %v.delegate.%v(%v)", idRecv, m.Name, delegateArgs)
+		}
+		g.out()
+		g.p("}")
+	}
+
 	var callArgs string
 	if m.Variadic == nil {
 		if len(argNames) > 0 {
@@ -600,7 +726,21 @@ func (g *generator) GenerateMockMethod(mockType string, m *model.Method, pkgOver
 		g.p(`%v.ctrl.Call(%v, %q%v)`, idRecv, idRecv, m.Name, callArgs)
 	} else {
 		idRet := ia.allocateIdentifier("ret")
-		g.p(`%v := %v.ctrl.Call(%v, %q%v)`, idRet, idRecv, idRecv, m.Name, callArgs)
+		if g.defaultReturn == defaultReturnError && rets[len(rets)-1] == "error" {
+			defaults := make([]string, len(rets))
+			for i := range defaults {
+				defaults[i] = "nil"
+			}
+			defaults[len(defaults)-1] = "gomock.ErrNotMocked"
+			g.p("%v := []any{%v}", idRet, strings.Join(defaults, ", "))
+			g.p("if %v.ctrl.HasExpectedCalls(%v, %q) {", idRecv, idRecv, m.Name)
+			g.in()
+			g.p(`%v = %v.ctrl.Call(%v, %q%v)`, idRet, idRecv, idRecv, m.Name, callArgs)
+			g.out()
+			g.p("}")
+		} else {
+			g.p(`%v := %v.ctrl.Call(%v, %q%v)`, idRet, idRecv, idRecv, m.Name, callArgs)
+		}
 
 		// Go does not allow "naked" type assertions on nil values, so we use the two-value form here.
 		// The value of that is either (x.(T), true) or (Z, false), where Z is the zero value for T.
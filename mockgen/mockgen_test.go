@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path"
@@ -467,3 +468,57 @@ func TestParseExcludeInterfaces(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerate_Deterministic(t *testing.T) {
+	newPackage := func() *model.Package {
+		intf := &model.Interface{Name: "Store"}
+		for _, m := range []*model.Method{
+			{Name: "Put", In: []*model.Parameter{{Name: "b", Type: &model.NamedType{Package: "bytes", Type: "Buffer"}}}},
+			{Name: "Get", Out: []*model.Parameter{{Type: &model.NamedType{Package: "time", Type: "Time"}}}},
+			{Name: "Delete", In: []*model.Parameter{{Name: "ctx", Type: &model.NamedType{Package: "context", Type: "Context"}}}},
+			{Name: "List", Out: []*model.Parameter{{Type: &model.NamedType{Package: "net/http", Type: "Header"}}}},
+		} {
+			intf.AddMethod(m)
+		}
+		return &model.Package{Name: "store", PkgPath: "example.com/store", Interfaces: []*model.Interface{intf}}
+	}
+	generate := func(methodOrder string) []byte {
+		g := generator{methodOrder: methodOrder}
+		if err := g.Generate(newPackage(), "mock_store", "example.com/mock_store"); err != nil {
+			t.Fatal(err)
+		}
+		return g.Output()
+	}
+	methodLines := func(src []byte) []string {
+		var names []string
+		for _, line := range strings.Split(string(src), "\n") {
+			rest, ok := strings.CutPrefix(line, "func (m *MockStore) ")
+			if !ok {
+				continue
+			}
+			name, _, _ := strings.Cut(rest, "(")
+			if name != "EXPECT" && name != "ISGOMOCK" {
+				names = append(names, name)
+			}
+		}
+		return names
+	}
+
+	for _, tt := range []struct {
+		order string
+		want  []string
+	}{
+		{methodOrderAlphabetical, []string{"Delete", "Get", "List", "Put"}},
+		{methodOrderSource, []string{"Put", "Get", "Delete", "List"}},
+	} {
+		t.Run(tt.order, func(t *testing.T) {
+			first, second := generate(tt.order), generate(tt.order)
+			if !bytes.Equal(first, second) {
+				t.Fatalf("generation is not deterministic:\n%s\n---\n%s", first, second)
+			}
+			if got := methodLines(first); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("method order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -59,6 +59,10 @@ type Interface struct {
 	Name       string
 	Methods    []*Method
 	TypeParams []*Parameter
+
+	// FuncType is set when the interface was synthesized from a named
+	// function type. Such an interface has a single method named Call.
+	FuncType bool
 }
 
 // Print writes the interface name and its methods.
@@ -79,6 +79,10 @@ func sourceMode(source string) (*model.Package, error) {
 		p.excludeNamesSet = parseExcludeInterfaces(*excludeInterfaces)
 	}
 
+	if *funcTypes != "" {
+		p.funcTypesSet = parseExcludeInterfaces(*funcTypes)
+	}
+
 	// Handle -aux_files.
 	if err := p.parseAuxFiles(*auxFiles); err != nil {
 		return nil, err
@@ -168,6 +172,7 @@ type fileParser struct {
 	auxInterfaces      *interfaceCache
 	srcDir             string
 	excludeNamesSet    map[string]struct{}
+	funcTypesSet       map[string]struct{}
 }
 
 func (p *fileParser) errorf(pos token.Pos, format string, args ...any) error {
@@ -240,6 +245,11 @@ func (p *fileParser) parseFile(importPath string, file *ast.File) (*model.Packag
 		}
 		is = append(is, i)
 	}
+	fs, err := p.parseFuncTypes(importPath, file)
+	if err != nil {
+		return nil, err
+	}
+	is = append(is, fs...)
 	return &model.Package{
 		Name:       file.Name.String(),
 		PkgPath:    importPath,
@@ -248,6 +258,51 @@ func (p *fileParser) parseFile(importPath string, file *ast.File) (*model.Packag
 	}, nil
 }
 
+// parseFuncTypes returns an interface with a single Call method for each
+// named function type in file that was requested with -func_types.
+func (p *fileParser) parseFuncTypes(pkg string, file *ast.File) ([]*model.Interface, error) {
+	if len(p.funcTypesSet) == 0 {
+		return nil, nil
+	}
+	found := make(map[string]bool)
+	var is []*model.Interface
+	for _, ts := range iterTypeSpecs(file) {
+		ft, ok := ts.Type.(*ast.FuncType)
+		if !ok {
+			continue
+		}
+		if _, ok := p.funcTypesSet[ts.Name.Name]; !ok {
+			continue
+		}
+		found[ts.Name.Name] = true
+
+		iface := &model.Interface{Name: ts.Name.Name, FuncType: true}
+		tps := make(map[string]model.Type)
+		typeParams := getTypeSpecTypeParams(ts)
+		for _, tp := range typeParams {
+			for _, tm := range tp.Names {
+				tps[tm.Name] = nil
+			}
+		}
+		var err error
+		if iface.TypeParams, err = p.parseFieldList(pkg, typeParams, tps); err != nil {
+			return nil, fmt.Errorf("unable to parse function type parameters: %v", ts.Name.Name)
+		}
+		m := &model.Method{Name: "Call"}
+		if m.In, m.Variadic, m.Out, err = p.parseFunc(pkg, ft, tps); err != nil {
+			return nil, err
+		}
+		iface.AddMethod(m)
+		is = append(is, iface)
+	}
+	for name := range p.funcTypesSet {
+		if !found[name] {
+			return nil, fmt.Errorf("function type %s not found in source", name)
+		}
+	}
+	return is, nil
+}
+
 // parsePackage loads package specified by path, parses it and returns
 // a new fileParser with the parsed imports and interfaces.
 func (p *fileParser) parsePackage(path string) (*fileParser, error) {
@@ -740,6 +795,23 @@ type namedInterface struct {
 	instTypes              []model.Type
 }
 
+// iterTypeSpecs returns all type specs declared at the top level of file.
+func iterTypeSpecs(file *ast.File) []*ast.TypeSpec {
+	var tss []*ast.TypeSpec
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			if ts, ok := spec.(*ast.TypeSpec); ok {
+				tss = append(tss, ts)
+			}
+		}
+	}
+	return tss
+}
+
 // Create an iterator over all interfaces in file.
 func iterInterfaces(file *ast.File) <-chan *namedInterface {
 	ch := make(chan *namedInterface)
@@ -3,6 +3,7 @@ package concurrent
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -87,3 +88,30 @@ func TestCancelWhenMocksSatisfied(t *testing.T) {
 	}
 	ctrl.Finish()
 }
+
+func TestConcurrentExpect(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := mock.NewMockMath(ctrl)
+
+	const n = 10
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			m.EXPECT().Sum(i, i).Return(2 * i)
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if got := m.Sum(i, i); got != 2*i {
+				t.Errorf("Sum(%d, %d) = %d, want %d", i, i, got, 2*i)
+			}
+		}(i)
+	}
+	wg.Wait()
+}